@@ -3,15 +3,20 @@ package arr
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"github.com/habibimustafa/collection/deep"
+	mapsort "github.com/habibimustafa/collection/sort"
 	"math"
+	"math/rand"
 	"reflect"
+	"sort"
 )
 
 // Array represents a list of array, slice, or map
 type Array []interface{}
 
-// List listing an array, slice, or map to an Array object
+// List listing an array, slice, or map to an Array object, map values are listed in the order of their keys
 func List(list interface{}) Array {
 	if list == nil {
 		return Array{}
@@ -27,13 +32,12 @@ func List(list interface{}) Array {
 		return c
 	case reflect.Map:
 		c := Array{}
-		for _, k := range val.MapKeys() {
-			c = append(c, val.MapIndex(k).Interface())
+		for _, v := range mapsort.Sort(val).Value {
+			c = append(c, v.Interface())
 		}
 		return c
 	default:
 		panic("list: list type must be a slice, array, map, or nil")
-		return nil
 	}
 }
 
@@ -172,16 +176,16 @@ func (a Array) WhenNotEmpty(callback func(collection Array) interface{}) Array {
 	return a
 }
 
-// Chunk splits items into separated array
-func (a Array) Chunk(size int) interface{} {
+// Chunk splits items into separated array, the size must be greater than zero
+func (a Array) Chunk(size int) []Array {
 	if size <= 0 {
-		return a
+		panic("the chunk size must be greater than zero")
 	}
 
 	length := len(a)
 	chunks := int(math.Ceil(float64(length) / float64(size)))
 
-	var newCollection Array
+	var newCollection []Array
 	for i, end := 0, 0; chunks > 0; chunks-- {
 		end = (i + 1) * size
 		if end > length {
@@ -193,3 +197,199 @@ func (a Array) Chunk(size int) interface{} {
 
 	return newCollection
 }
+
+// Unique removes repeated items, numbers of different types with the same value are treated as repeats
+func (a Array) Unique() Array {
+	return a.dedupe(deep.NewNumericSet(), func(item interface{}, index int) interface{} { return item })
+}
+
+// UniqueBy removes items whose callback result repeats an earlier item
+func (a Array) UniqueBy(callback func(item interface{}, index int) interface{}) Array {
+	return a.dedupe(deep.NewNumericSet(), callback)
+}
+
+// DedupeStrict removes repeated items, items must have the same type to be treated as repeats
+func (a Array) DedupeStrict() Array {
+	return a.dedupe(deep.NewSet(), func(item interface{}, index int) interface{} { return item })
+}
+
+// Duplicates gets the indexes of items that repeat an earlier item
+func (a Array) Duplicates() []int {
+	var indexes []int
+	seen := deep.NewNumericSet()
+	for i, item := range a {
+		if !seen.Add(item) {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+func (a Array) dedupe(seen *deep.Set, callback func(item interface{}, index int) interface{}) Array {
+	newCollection := Array{}
+	for i, item := range a {
+		if seen.Add(callback(item, i)) {
+			newCollection = append(newCollection, item)
+		}
+	}
+	return newCollection
+}
+
+// Shuffle gets the items in a random order decided by the source
+func (a Array) Shuffle(source rand.Source) Array {
+	newCollection := Array{}
+	for _, i := range rand.New(source).Perm(a.Size()) {
+		newCollection = append(newCollection, a[i])
+	}
+	return newCollection
+}
+
+// Random gets n random items, the same item can be picked more than once
+func (a Array) Random(source rand.Source, n int) Array {
+	if n > 0 && a.IsEmpty() {
+		panic("cannot get random items from empty array")
+	}
+
+	random := rand.New(source)
+	newCollection := Array{}
+	for i := 0; i < n; i++ {
+		newCollection = append(newCollection, a[random.Intn(a.Size())])
+	}
+	return newCollection
+}
+
+// Sample gets n random items, each item can be picked only once, a size of zero or less gets no items
+func (a Array) Sample(source rand.Source, n int) Array {
+	if n > a.Size() {
+		panic("the sample size is larger than the array size")
+	}
+
+	if n <= 0 {
+		return Array{}
+	}
+
+	newCollection := Array{}
+	for _, i := range rand.New(source).Perm(a.Size())[:n] {
+		newCollection = append(newCollection, a[i])
+	}
+	return newCollection
+}
+
+// WeightedSample gets n random items where items with larger weight are more likely to be picked,
+// each item can be picked only once and items with zero or negative weight are never picked.
+// A size of zero or less gets no items.
+func (a Array) WeightedSample(source rand.Source, n int, weight func(item interface{}, index int) float64) Array {
+	if n > a.Size() {
+		panic("the sample size is larger than the array size")
+	}
+
+	if n <= 0 {
+		return Array{}
+	}
+
+	// Efraimidis-Spirakis: pick the items with the largest u^(1/w) keys
+	type candidate struct {
+		index int
+		key   float64
+	}
+
+	random := rand.New(source)
+	var candidates []candidate
+	for i, item := range a {
+		if w := weight(item, i); w > 0 {
+			candidates = append(candidates, candidate{i, math.Pow(random.Float64(), 1/w)})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].key > candidates[j].key })
+	if n > len(candidates) {
+		n = len(candidates)
+	}
+
+	newCollection := Array{}
+	for _, c := range candidates[:n] {
+		newCollection = append(newCollection, a[c.index])
+	}
+	return newCollection
+}
+
+// ErrItemNotFound is returned when no item matches
+var ErrItemNotFound = errors.New("no item matches")
+
+// ErrMultipleItemsFound is returned when more than one item matches
+var ErrMultipleItemsFound = errors.New("more than one item matches")
+
+// FirstWhere gets the first item that passes the callback
+func (a Array) FirstWhere(callback func(item interface{}, index int) bool) (interface{}, bool) {
+	index := a.SearchFunc(callback)
+	if index < 0 {
+		return nil, false
+	}
+	return a[index], true
+}
+
+// LastWhere gets the last item that passes the callback
+func (a Array) LastWhere(callback func(item interface{}, index int) bool) (interface{}, bool) {
+	for i := a.Size() - 1; i >= 0; i-- {
+		if callback(a[i], i) {
+			return a[i], true
+		}
+	}
+	return nil, false
+}
+
+// FirstOr gets the first item, or the default value when array is empty
+func (a Array) FirstOr(defaultValue interface{}) interface{} {
+	if a.IsEmpty() {
+		return defaultValue
+	}
+	return a[0]
+}
+
+// Sole gets the only item that passes the callback,
+// it fails when no item or more than one item passes
+func (a Array) Sole(callback func(item interface{}, index int) bool) (interface{}, error) {
+	matches := a.Filter(callback)
+	switch matches.Size() {
+	case 0:
+		return nil, ErrItemNotFound
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, ErrMultipleItemsFound
+	}
+}
+
+// Search gets the index of the first item deeply equal to the value, or -1 when not found
+func (a Array) Search(value interface{}) int {
+	return a.SearchFunc(func(item interface{}, index int) bool {
+		return deep.Equal(item, value)
+	})
+}
+
+// SearchFunc gets the index of the first item that passes the callback, or -1 when not found
+func (a Array) SearchFunc(callback func(item interface{}, index int) bool) int {
+	for i, item := range a {
+		if callback(item, i) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Every is every item passes the callback, it is true for an empty array
+func (a Array) Every(callback func(item interface{}, index int) bool) bool {
+	return !a.Some(func(item interface{}, index int) bool {
+		return !callback(item, index)
+	})
+}
+
+// Some is at least one item passes the callback
+func (a Array) Some(callback func(item interface{}, index int) bool) bool {
+	return a.SearchFunc(callback) > -1
+}
+
+// None is no item passes the callback
+func (a Array) None(callback func(item interface{}, index int) bool) bool {
+	return !a.Some(callback)
+}
@@ -3,6 +3,7 @@ package arr
 import (
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"math/rand"
 	"testing"
 )
 
@@ -30,6 +31,8 @@ func TestCreateArray(t *testing.T) {
 	assert.Equal(t, len(arrMap), len(mapArray))
 	assert.Equal(t, len(arrMap), mapArray.Size())
 	assert.Equal(t, "John Doe", mapArray.Implode(" "))
+
+	assert.Equal(t, Array{"a", "b", "c"}, List(map[int]string{3: "c", 1: "a", 2: "b"}))
 }
 
 func TestArrayGetAllItems(t *testing.T) {
@@ -165,35 +168,178 @@ func TestArrayWhenNotEmptyOnNotEmpty(t *testing.T) {
 func TestArrayChunk(t *testing.T) {
 	array := Array{"2607", "f0d0", "1002", "0051", "0000", "0000", "0000", "0004"}
 
-	expected := Array{
-		Array{"2607", "f0d0", "1002", "0051", "0000", "0000", "0000", "0004"},
+	expected := []Array{
+		{"2607", "f0d0", "1002", "0051", "0000", "0000", "0000", "0004"},
 	}
 
 	assert.Equal(t, expected, array.Chunk(8))
 
-	expected = Array{
-		Array{"2607", "f0d0", "1002", "0051", "0000", "0000"},
-		Array{"0000", "0004"},
+	expected = []Array{
+		{"2607", "f0d0", "1002", "0051", "0000", "0000"},
+		{"0000", "0004"},
 	}
 
 	assert.Equal(t, expected, array.Chunk(6))
 
-	expected = Array{
-		Array{"2607", "f0d0", "1002", "0051"},
-		Array{"0000", "0000", "0000", "0004"},
+	expected = []Array{
+		{"2607", "f0d0", "1002", "0051"},
+		{"0000", "0000", "0000", "0004"},
 	}
 
 	assert.Equal(t, expected, array.Chunk(4))
 
-	expected = Array{
-		Array{"2607", "f0d0"},
-		Array{"1002", "0051"},
-		Array{"0000", "0000"},
-		Array{"0000", "0004"},
+	expected = []Array{
+		{"2607", "f0d0"},
+		{"1002", "0051"},
+		{"0000", "0000"},
+		{"0000", "0004"},
 	}
 
 	assert.Equal(t, expected, array.Chunk(2))
 
-	assert.Equal(t, array, array.Chunk(0))
-	assert.Equal(t, array, array.Chunk(-1))
+	assert.Nil(t, Array{}.Chunk(2))
+	assert.PanicsWithValue(t, "the chunk size must be greater than zero", func() { array.Chunk(0) })
+	assert.PanicsWithValue(t, "the chunk size must be greater than zero", func() { array.Chunk(-1) })
+	assert.PanicsWithValue(t, "the chunk size must be greater than zero", func() { Array{}.Chunk(0) })
+}
+
+func TestArrayUnique(t *testing.T) {
+	array := Array{"a", 1, "b", 1.0, "a", []int{1}, []int{1}, int64(2)}
+	assert.Equal(t, Array{"a", 1, "b", []int{1}, int64(2)}, array.Unique())
+	assert.Equal(t, Array{}, Array{}.Unique())
+}
+
+func TestArrayUniqueBy(t *testing.T) {
+	array := Array{"apple", "avocado", "banana", "blueberry", "cherry"}
+	unique := array.UniqueBy(func(item interface{}, index int) interface{} {
+		return item.(string)[0]
+	})
+
+	assert.Equal(t, Array{"apple", "banana", "cherry"}, unique)
+}
+
+func TestArrayDedupeStrict(t *testing.T) {
+	array := Array{1, 1.0, int64(1), 1, map[string]int{"a": 1}, map[string]int{"a": 1}}
+	assert.Equal(t, Array{1, 1.0, int64(1), map[string]int{"a": 1}}, array.DedupeStrict())
+}
+
+func TestArrayDuplicates(t *testing.T) {
+	array := Array{"a", 1, "b", 1.0, "a", []int{1}, []int{1}}
+	assert.Equal(t, []int{3, 4, 6}, array.Duplicates())
+	assert.Nil(t, Array{"a", "b"}.Duplicates())
+}
+
+func TestArrayShuffle(t *testing.T) {
+	array := Array{1, 2, 3, 4, 5, 6, 7, 8}
+	shuffled := array.Shuffle(rand.NewSource(42))
+
+	assert.Equal(t, shuffled, array.Shuffle(rand.NewSource(42)))
+	assert.NotEqual(t, array, shuffled)
+	assert.ElementsMatch(t, array, shuffled)
+	assert.Equal(t, Array{1, 2, 3, 4, 5, 6, 7, 8}, array)
+}
+
+func TestArrayRandom(t *testing.T) {
+	array := Array{"a", "b", "c"}
+	random := array.Random(rand.NewSource(42), 10)
+
+	assert.Equal(t, 10, random.Size())
+	assert.Equal(t, random, array.Random(rand.NewSource(42), 10))
+	random.Each(func(item interface{}, index int) { assert.True(t, array.Has(item)) })
+	assert.Equal(t, Array{}, Array{}.Random(rand.NewSource(42), 0))
+	assert.PanicsWithValue(t, "cannot get random items from empty array", func() { Array{}.Random(rand.NewSource(42), 1) })
+}
+
+func TestArraySample(t *testing.T) {
+	array := Array{1, 2, 3, 4, 5, 6, 7, 8}
+	sample := array.Sample(rand.NewSource(42), 4)
+
+	assert.Equal(t, 4, sample.Size())
+	assert.Equal(t, sample, array.Sample(rand.NewSource(42), 4))
+	assert.Equal(t, sample, sample.Unique())
+	assert.ElementsMatch(t, array, array.Sample(rand.NewSource(7), 8))
+	assert.PanicsWithValue(t, "the sample size is larger than the array size", func() { array.Sample(rand.NewSource(42), 9) })
+	assert.Equal(t, Array{}, array.Sample(rand.NewSource(42), 0))
+	assert.Equal(t, Array{}, array.Sample(rand.NewSource(42), -1))
+}
+
+func TestArrayWeightedSample(t *testing.T) {
+	array := Array{"never", "rare", "often"}
+	weight := func(item interface{}, index int) float64 { return []float64{0, 1, 1000}[index] }
+
+	sample := array.WeightedSample(rand.NewSource(42), 2, weight)
+	assert.Equal(t, sample, array.WeightedSample(rand.NewSource(42), 2, weight))
+	assert.Equal(t, Array{"often", "rare"}, sample)
+	assert.Equal(t, Array{"often", "rare"}, array.WeightedSample(rand.NewSource(42), 3, weight))
+	assert.Equal(t, Array{}, array.WeightedSample(rand.NewSource(42), -1, weight))
+
+	often := 0
+	for seed := int64(0); seed < 100; seed++ {
+		if array.WeightedSample(rand.NewSource(seed), 1, weight).First() == "often" {
+			often++
+		}
+	}
+	assert.Greater(t, often, 90)
+}
+
+func isEven(item interface{}, index int) bool {
+	return item.(int)%2 == 0
+}
+
+func TestArrayFirstWhereAndLastWhere(t *testing.T) {
+	array := Array{1, 2, 3, 4, 5}
+
+	first, ok := array.FirstWhere(isEven)
+	assert.True(t, ok)
+	assert.Equal(t, 2, first)
+
+	last, ok := array.LastWhere(isEven)
+	assert.True(t, ok)
+	assert.Equal(t, 4, last)
+
+	_, ok = Array{1, 3}.FirstWhere(isEven)
+	assert.False(t, ok)
+	_, ok = Array{}.LastWhere(isEven)
+	assert.False(t, ok)
+}
+
+func TestArrayFirstOr(t *testing.T) {
+	assert.Equal(t, "Hello", Array{"Hello", "World"}.FirstOr("Hi"))
+	assert.Equal(t, "Hi", Array{}.FirstOr("Hi"))
+}
+
+func TestArraySole(t *testing.T) {
+	sole, err := Array{1, 2, 3}.Sole(isEven)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, sole)
+
+	_, err = Array{1, 3}.Sole(isEven)
+	assert.Equal(t, ErrItemNotFound, err)
+
+	_, err = Array{2, 4}.Sole(isEven)
+	assert.Equal(t, ErrMultipleItemsFound, err)
+}
+
+func TestArraySearch(t *testing.T) {
+	array := Array{"Hello", []int{1, 2}, map[string]int{"a": 1}}
+	assert.Equal(t, 0, array.Search("Hello"))
+	assert.Equal(t, 1, array.Search([]int{1, 2}))
+	assert.Equal(t, 2, array.Search(map[string]int{"a": 1}))
+	assert.Equal(t, -1, array.Search([]int{1}))
+
+	assert.Equal(t, 3, Array{1, 3, 5, 6}.SearchFunc(isEven))
+	assert.Equal(t, -1, Array{1, 3}.SearchFunc(isEven))
+}
+
+func TestArrayEverySomeNone(t *testing.T) {
+	assert.True(t, Array{2, 4}.Every(isEven))
+	assert.False(t, Array{2, 3}.Every(isEven))
+	assert.True(t, Array{}.Every(isEven))
+
+	assert.True(t, Array{1, 2}.Some(isEven))
+	assert.False(t, Array{1, 3}.Some(isEven))
+	assert.False(t, Array{}.Some(isEven))
+
+	assert.True(t, Array{1, 3}.None(isEven))
+	assert.False(t, Array{1, 2}.None(isEven))
 }
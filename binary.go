@@ -0,0 +1,498 @@
+package collection
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"github.com/habibimustafa/collection/arr"
+	"io"
+	"math"
+	"reflect"
+	"sync"
+)
+
+// The binary format starts with the "COL" magic and the format version,
+// followed by the root collection. Every value is written as a tag byte and its content:
+//
+//  - a collection is its key equaler id, the number of items and every key and value in order
+//  - an arr.Array or a []interface{} is the number of items and every item in order
+//  - numbers keep their exact type, integers are written as varints
+//  - values of registered types are their type name and their gob encoding
+//
+// Key equalers are kept when they are NumericKeys, DeepKeys, or the case folding equaler of CollectFold.
+// Other equalers, including the normalizers of WithKeyNormalizer, cannot be encoded.
+
+const binaryMagic = "COL"
+
+const binaryVersion byte = 1
+
+// value tags of the binary format
+const (
+	binaryNil byte = iota
+	binaryBool
+	binaryInt
+	binaryUint
+	binaryFloat
+	binaryComplex
+	binaryString
+	binaryBytes
+	binaryCollection
+	binaryArray
+	binarySlice
+	binaryRegistered
+)
+
+// key equaler ids of the binary format
+const (
+	binaryNoEqualer byte = iota
+	binaryNumericKeys
+	binaryDeepKeys
+	binaryFoldKeys
+)
+
+// basicTypes are the predeclared types encoded without registration, by kind
+var basicTypes = map[reflect.Kind]reflect.Type{
+	reflect.Bool:       reflect.TypeOf(false),
+	reflect.Int:        reflect.TypeOf(int(0)),
+	reflect.Int8:       reflect.TypeOf(int8(0)),
+	reflect.Int16:      reflect.TypeOf(int16(0)),
+	reflect.Int32:      reflect.TypeOf(int32(0)),
+	reflect.Int64:      reflect.TypeOf(int64(0)),
+	reflect.Uint:       reflect.TypeOf(uint(0)),
+	reflect.Uint8:      reflect.TypeOf(uint8(0)),
+	reflect.Uint16:     reflect.TypeOf(uint16(0)),
+	reflect.Uint32:     reflect.TypeOf(uint32(0)),
+	reflect.Uint64:     reflect.TypeOf(uint64(0)),
+	reflect.Uintptr:    reflect.TypeOf(uintptr(0)),
+	reflect.Float32:    reflect.TypeOf(float32(0)),
+	reflect.Float64:    reflect.TypeOf(float64(0)),
+	reflect.Complex64:  reflect.TypeOf(complex64(0)),
+	reflect.Complex128: reflect.TypeOf(complex128(0)),
+	reflect.String:     reflect.TypeOf(""),
+}
+
+var types = struct {
+	sync.RWMutex
+	byName map[string]reflect.Type
+	byType map[reflect.Type]string
+}{byName: map[string]reflect.Type{}, byType: map[reflect.Type]string{}}
+
+// Collections implement the gob and binary interfaces without listing them in the Collection interface,
+// otherwise gob would treat Collection fields as encoders and fail to decode them
+var (
+	_ gob.GobEncoder             = collect{}
+	_ gob.GobDecoder             = &collect{}
+	_ encoding.BinaryMarshaler   = collect{}
+	_ encoding.BinaryUnmarshaler = &collect{}
+)
+
+func init() {
+	gob.RegisterName("github.com/habibimustafa/collection.Collection", collect{})
+}
+
+// RegisterType allows the values of the same type as the value to be binary encoded inside collections.
+// The values are encoded with encoding/gob, so the type must be encodable by gob.
+// Predeclared types, collections, arr.Array and []interface{} do not need to be registered.
+// It is safe to register types from several goroutines.
+func RegisterType(value interface{}) {
+	if value == nil {
+		panic("the registered value must not be nil")
+	}
+
+	t := reflect.TypeOf(value)
+	name := t.String()
+	if t.Name() != "" && t.PkgPath() != "" {
+		name = t.PkgPath() + "." + t.Name()
+	}
+
+	types.Lock()
+	defer types.Unlock()
+	types.byName[name] = t
+	types.byType[t] = name
+}
+
+func registeredName(t reflect.Type) (string, bool) {
+	types.RLock()
+	defer types.RUnlock()
+	name, ok := types.byType[t]
+	return name, ok
+}
+
+func registeredType(name string) (reflect.Type, bool) {
+	types.RLock()
+	defer types.RUnlock()
+	t, ok := types.byName[name]
+	return t, ok
+}
+
+// MarshalBinary encodes the collection in the versioned binary format, keeping the order of the items.
+// It fails when a value is neither a predeclared type, a collection, an arr.Array,
+// a []interface{}, nor a registered type, and when a collection has a key equaler other than
+// NumericKeys, DeepKeys, or the case folding equaler of CollectFold.
+func (c collect) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(binaryMagic)
+	buf.WriteByte(binaryVersion)
+	if err := writeCollection(&buf, c); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes a collection encoded by MarshalBinary
+func (c *collect) UnmarshalBinary(data []byte) error {
+	if !bytes.HasPrefix(data, []byte(binaryMagic)) || len(data) <= len(binaryMagic) {
+		return errInvalidBinary
+	}
+
+	if version := data[len(binaryMagic)]; version != binaryVersion {
+		return fmt.Errorf("collection: unsupported binary version %d", version)
+	}
+
+	r := bytes.NewReader(data[len(binaryMagic)+1:])
+	decoded, err := readCollection(r)
+	if err != nil {
+		return err
+	}
+
+	if r.Len() > 0 {
+		return errInvalidBinary
+	}
+
+	*c = decoded
+	return nil
+}
+
+// GobEncode encodes the collection for encoding/gob in the binary format, it fails like MarshalBinary
+func (c collect) GobEncode() ([]byte, error) {
+	return c.MarshalBinary()
+}
+
+// GobDecode decodes a collection encoded by GobEncode
+func (c *collect) GobDecode(data []byte) error {
+	return c.UnmarshalBinary(data)
+}
+
+// FromBinary decodes a collection encoded by MarshalBinary
+func FromBinary(data []byte) (Collection, error) {
+	c := collect{}
+	if err := c.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func writeCollection(buf *bytes.Buffer, c collect) error {
+	switch c.equaler.(type) {
+	case nil:
+		buf.WriteByte(binaryNoEqualer)
+	case NumericKeys:
+		buf.WriteByte(binaryNumericKeys)
+	case DeepKeys:
+		buf.WriteByte(binaryDeepKeys)
+	case normalizedKeys:
+		if !c.equaler.(normalizedKeys).fold {
+			return errors.New("collection: cannot encode the key normalizer of WithKeyNormalizer")
+		}
+		buf.WriteByte(binaryFoldKeys)
+	default:
+		return fmt.Errorf("collection: cannot encode key equaler %T", c.equaler)
+	}
+
+	writeUvarint(buf, uint64(c.Size()))
+	for i := range c.keys {
+		if err := writeValue(buf, c.keys[i]); err != nil {
+			return err
+		}
+		if err := writeValue(buf, c.values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeValue(buf *bytes.Buffer, value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		buf.WriteByte(binaryNil)
+		return nil
+	case collect:
+		buf.WriteByte(binaryCollection)
+		return writeCollection(buf, v)
+	case arr.Array:
+		buf.WriteByte(binaryArray)
+		return writeItems(buf, v)
+	case []interface{}:
+		buf.WriteByte(binarySlice)
+		return writeItems(buf, v)
+	case []byte:
+		buf.WriteByte(binaryBytes)
+		writeUvarint(buf, uint64(len(v)))
+		buf.Write(v)
+		return nil
+	}
+
+	val := reflect.ValueOf(value)
+	if val.Type() == basicTypes[val.Kind()] {
+		switch val.Kind() {
+		case reflect.Bool:
+			buf.WriteByte(binaryBool)
+			if val.Bool() {
+				buf.WriteByte(1)
+			} else {
+				buf.WriteByte(0)
+			}
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			buf.Write([]byte{binaryInt, byte(val.Kind())})
+			var b [binary.MaxVarintLen64]byte
+			buf.Write(b[:binary.PutVarint(b[:], val.Int())])
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			buf.Write([]byte{binaryUint, byte(val.Kind())})
+			writeUvarint(buf, val.Uint())
+		case reflect.Float32, reflect.Float64:
+			buf.Write([]byte{binaryFloat, byte(val.Kind())})
+			writeFloat(buf, val.Float())
+		case reflect.Complex64, reflect.Complex128:
+			buf.Write([]byte{binaryComplex, byte(val.Kind())})
+			writeFloat(buf, real(val.Complex()))
+			writeFloat(buf, imag(val.Complex()))
+		case reflect.String:
+			buf.WriteByte(binaryString)
+			writeString(buf, val.String())
+		}
+		return nil
+	}
+
+	name, ok := registeredName(val.Type())
+	if !ok {
+		return fmt.Errorf("collection: cannot encode value of unregistered type %T", value)
+	}
+
+	var encoded bytes.Buffer
+	if err := gob.NewEncoder(&encoded).EncodeValue(val); err != nil {
+		return fmt.Errorf("collection: cannot encode value of type %T: %w", value, err)
+	}
+
+	buf.WriteByte(binaryRegistered)
+	writeString(buf, name)
+	writeUvarint(buf, uint64(encoded.Len()))
+	buf.Write(encoded.Bytes())
+	return nil
+}
+
+func writeItems(buf *bytes.Buffer, items []interface{}) error {
+	writeUvarint(buf, uint64(len(items)))
+	for _, item := range items {
+		if err := writeValue(buf, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeUvarint(buf *bytes.Buffer, n uint64) {
+	var b [binary.MaxVarintLen64]byte
+	buf.Write(b[:binary.PutUvarint(b[:], n)])
+}
+
+func writeFloat(buf *bytes.Buffer, f float64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], math.Float64bits(f))
+	buf.Write(b[:])
+}
+
+func writeString(buf *bytes.Buffer, s string) {
+	writeUvarint(buf, uint64(len(s)))
+	buf.WriteString(s)
+}
+
+// errInvalidBinary is returned when the binary data ends early or holds unexpected content
+var errInvalidBinary = errors.New("collection: invalid binary data")
+
+func readCollection(r *bytes.Reader) (collect, error) {
+	c := collect{}
+	id, err := r.ReadByte()
+	if err != nil {
+		return c, errInvalidBinary
+	}
+
+	switch id {
+	case binaryNoEqualer:
+	case binaryNumericKeys:
+		c.equaler = NumericKeys{}
+	case binaryDeepKeys:
+		c.equaler = DeepKeys{}
+	case binaryFoldKeys:
+		c.equaler = foldKeys
+	default:
+		return c, errInvalidBinary
+	}
+
+	size, err := readSize(r)
+	if err != nil {
+		return c, err
+	}
+
+	for i := 0; i < size; i++ {
+		key, err := readValue(r)
+		if err != nil {
+			return c, err
+		}
+
+		value, err := readValue(r)
+		if err != nil {
+			return c, err
+		}
+
+		c.keys = append(c.keys, key)
+		c.values = append(c.values, value)
+	}
+	return c, nil
+}
+
+func readValue(r *bytes.Reader) (interface{}, error) {
+	tag, err := r.ReadByte()
+	if err != nil {
+		return nil, errInvalidBinary
+	}
+
+	switch tag {
+	case binaryNil:
+		return nil, nil
+	case binaryBool:
+		b, err := r.ReadByte()
+		if err != nil || b > 1 {
+			return nil, errInvalidBinary
+		}
+		return b == 1, nil
+	case binaryInt, binaryUint, binaryFloat, binaryComplex:
+		return readNumber(r, tag)
+	case binaryString:
+		s, err := readBytes(r)
+		return string(s), err
+	case binaryBytes:
+		return readBytes(r)
+	case binaryCollection:
+		return readCollection(r)
+	case binaryArray, binarySlice:
+		size, err := readSize(r)
+		if err != nil {
+			return nil, err
+		}
+
+		items := make([]interface{}, size)
+		for i := range items {
+			if items[i], err = readValue(r); err != nil {
+				return nil, err
+			}
+		}
+
+		if tag == binaryArray {
+			return arr.Array(items), nil
+		}
+		return items, nil
+	case binaryRegistered:
+		name, err := readBytes(r)
+		if err != nil {
+			return nil, err
+		}
+
+		t, ok := registeredType(string(name))
+		if !ok {
+			return nil, fmt.Errorf("collection: cannot decode value of unregistered type %q", name)
+		}
+
+		encoded, err := readBytes(r)
+		if err != nil {
+			return nil, err
+		}
+
+		val := reflect.New(t)
+		if err := gob.NewDecoder(bytes.NewReader(encoded)).DecodeValue(val); err != nil {
+			return nil, fmt.Errorf("collection: cannot decode value of type %s: %w", t, err)
+		}
+		return val.Elem().Interface(), nil
+	default:
+		return nil, errInvalidBinary
+	}
+}
+
+func readNumber(r *bytes.Reader, tag byte) (interface{}, error) {
+	kind, err := r.ReadByte()
+	if err != nil {
+		return nil, errInvalidBinary
+	}
+
+	t, ok := basicTypes[reflect.Kind(kind)]
+	if !ok {
+		return nil, errInvalidBinary
+	}
+
+	var val reflect.Value
+	switch {
+	case tag == binaryInt && kind >= byte(reflect.Int) && kind <= byte(reflect.Int64):
+		n, err := binary.ReadVarint(r)
+		if err != nil {
+			return nil, errInvalidBinary
+		}
+		val = reflect.ValueOf(n)
+	case tag == binaryUint && kind >= byte(reflect.Uint) && kind <= byte(reflect.Uintptr):
+		n, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, errInvalidBinary
+		}
+		val = reflect.ValueOf(n)
+	case tag == binaryFloat && (kind == byte(reflect.Float32) || kind == byte(reflect.Float64)):
+		f, err := readFloat(r)
+		if err != nil {
+			return nil, err
+		}
+		val = reflect.ValueOf(f)
+	case tag == binaryComplex && (kind == byte(reflect.Complex64) || kind == byte(reflect.Complex128)):
+		re, err := readFloat(r)
+		if err != nil {
+			return nil, err
+		}
+		im, err := readFloat(r)
+		if err != nil {
+			return nil, err
+		}
+		val = reflect.ValueOf(complex(re, im))
+	default:
+		return nil, errInvalidBinary
+	}
+	return val.Convert(t).Interface(), nil
+}
+
+func readFloat(r *bytes.Reader) (float64, error) {
+	var b [8]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return 0, errInvalidBinary
+	}
+	return math.Float64frombits(binary.LittleEndian.Uint64(b[:])), nil
+}
+
+// readSize reads a number of items, it cannot be larger than the remaining bytes
+func readSize(r *bytes.Reader) (int, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil || n > uint64(r.Len()) {
+		return 0, errInvalidBinary
+	}
+	return int(n), nil
+}
+
+func readBytes(r *bytes.Reader) ([]byte, error) {
+	size, err := readSize(r)
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, size)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, errInvalidBinary
+	}
+	return b, nil
+}
@@ -0,0 +1,104 @@
+package collection
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"github.com/habibimustafa/collection/arr"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func init() {
+	RegisterType(point{})
+	RegisterType(time.Time{})
+}
+
+func TestCollectionMarshalBinary(t *testing.T) {
+	c := Collect(map[string]interface{}{
+		"int":     int8(-3),
+		"uint":    uint16(7),
+		"float":   float32(1.5),
+		"complex": complex(1, 2),
+		"string":  "Hello",
+		"bytes":   []byte("raw"),
+		"bool":    true,
+		"nil":     nil,
+		"array":   arr.Array{1, "a", nil},
+		"slice":   []interface{}{2.5, false},
+		"nested":  Collect([]string{"x", "y"}),
+		"point":   point{1, 2},
+		"time":    time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC),
+	})
+
+	data, err := c.(encoding.BinaryMarshaler).MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("COL\x01"), data[:4])
+
+	decoded, err := FromBinary(data)
+	assert.NoError(t, err)
+	assert.Equal(t, c, decoded)
+	assert.Equal(t, c.Keys(), decoded.Keys())
+}
+
+func TestCollectionMarshalBinaryKeepsEqualer(t *testing.T) {
+	frequencies := Collect([]interface{}{1, 1.0, "a"}).Frequencies()
+
+	data, err := frequencies.(encoding.BinaryMarshaler).MarshalBinary()
+	assert.NoError(t, err)
+
+	decoded, err := FromBinary(data)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, decoded.GetValue(int64(1)))
+
+	folded := CollectFold(map[string]interface{}{"Name": "Ann", "nested": CollectFold(map[string]int{"ID": 1})})
+	data, err = folded.(encoding.BinaryMarshaler).MarshalBinary()
+	assert.NoError(t, err)
+
+	decoded, err = FromBinary(data)
+	assert.NoError(t, err)
+	assert.Equal(t, "Ann", decoded.GetValue("NAME"))
+	assert.Equal(t, 1, decoded.GetValue("Nested").(Collection).GetValue("id"))
+	assert.True(t, decoded.Equals(folded))
+
+	_, err = Collect(arrMap, WithKeyNormalizer(strings.TrimSpace)).(encoding.BinaryMarshaler).MarshalBinary()
+	assert.EqualError(t, err, "collection: cannot encode the key normalizer of WithKeyNormalizer")
+}
+
+func TestCollectionMarshalBinaryErrors(t *testing.T) {
+	type unregistered struct{}
+	_, err := Collect([]interface{}{unregistered{}}).(encoding.BinaryMarshaler).MarshalBinary()
+	assert.EqualError(t, err, "collection: cannot encode value of unregistered type collection.unregistered")
+
+	_, err = FromBinary([]byte("JSON"))
+	assert.EqualError(t, err, "collection: invalid binary data")
+
+	_, err = FromBinary([]byte("COL\x02"))
+	assert.EqualError(t, err, "collection: unsupported binary version 2")
+
+	data, _ := Collect(arrString).(encoding.BinaryMarshaler).MarshalBinary()
+	for i := 4; i < len(data); i++ {
+		_, err = FromBinary(data[:i])
+		assert.EqualError(t, err, "collection: invalid binary data")
+	}
+}
+
+func TestCollectionGob(t *testing.T) {
+	type cache struct {
+		Name  string
+		Items Collection
+	}
+
+	in := cache{Name: "users", Items: Collect(arrMap).Append("Tags", Collect([]string{"a"}))}
+
+	var buf bytes.Buffer
+	assert.NoError(t, gob.NewEncoder(&buf).Encode(in))
+
+	var out cache
+	assert.NoError(t, gob.NewDecoder(&buf).Decode(&out))
+	assert.Equal(t, "users", out.Name)
+	assert.True(t, in.Items.DeepEquals(out.Items))
+	assert.Equal(t, in.Items.Keys(), out.Items.Keys())
+}
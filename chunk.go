@@ -0,0 +1,107 @@
+package collection
+
+// Chunk splits items into a collection of sub collections with the given size, the size must be greater than zero
+func (c collect) Chunk(size int) Collection {
+	if size <= 0 {
+		panic("the chunk size must be greater than zero")
+	}
+
+	var chunks collect
+	for start := 0; start < c.Size(); start += size {
+		end := start + size
+		if end > c.Size() {
+			end = c.Size()
+		}
+		chunks = chunks.push(c.slice(start, end))
+	}
+
+	return chunks
+}
+
+// Sliding gets a collection of sliding windows with the given size and step
+func (c collect) Sliding(size int, step int) Collection {
+	if size <= 0 {
+		panic("the window size must be greater than zero")
+	}
+
+	if step <= 0 {
+		panic("the window step must be greater than zero")
+	}
+
+	var windows collect
+	for start := 0; start+size <= c.Size(); start += step {
+		windows = windows.push(c.slice(start, start+size))
+	}
+
+	return windows
+}
+
+// ChunkWhile splits items into sub collections while the callback returns true
+func (c collect) ChunkWhile(callback func(value interface{}, key interface{}, chunk Collection) bool) Collection {
+	var chunks collect
+	start := 0
+	for i := 1; i < c.Size(); i++ {
+		if !callback(c.values[i], c.keys[i], c.slice(start, i)) {
+			chunks = chunks.push(c.slice(start, i))
+			start = i
+		}
+	}
+
+	if c.Size() > 0 {
+		chunks = chunks.push(c.slice(start, c.Size()))
+	}
+
+	return chunks
+}
+
+// SplitIn splits items into the given number of balanced groups
+func (c collect) SplitIn(groups int) Collection {
+	if groups <= 0 {
+		panic("the number of groups must be greater than zero")
+	}
+
+	size := c.Size() / groups
+	remainder := c.Size() % groups
+
+	var chunks collect
+	for i, start := 0, 0; i < groups && start < c.Size(); i++ {
+		end := start + size
+		if i < remainder {
+			end++
+		}
+		chunks = chunks.push(c.slice(start, end))
+		start = end
+	}
+
+	return chunks
+}
+
+// Partition separates items that pass the callback from the items that fail
+func (c collect) Partition(callback func(value interface{}, key interface{}, index int) bool) (Collection, Collection) {
+	passed, failed := c.with(nil, nil), c.with(nil, nil)
+	for i := 0; i < c.Size(); i++ {
+		if callback(c.values[i], c.keys[i], i) {
+			passed.keys = append(passed.keys, c.keys[i])
+			passed.values = append(passed.values, c.values[i])
+			continue
+		}
+
+		failed.keys = append(failed.keys, c.keys[i])
+		failed.values = append(failed.values, c.values[i])
+	}
+
+	return passed, failed
+}
+
+// slice copies the items between start and end into a new collection
+func (c collect) slice(start int, end int) collect {
+	return c.with(append([]interface{}{}, c.keys[start:end]...), append([]interface{}{}, c.values[start:end]...))
+}
+
+// push appends a value keyed by its position to the collection
+func (c collect) push(value interface{}) collect {
+	return collect{
+		keys:   append(c.keys, c.Size()),
+		values: append(c.values, value),
+	}
+}
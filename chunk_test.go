@@ -0,0 +1,83 @@
+package collection
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCollectionChunk(t *testing.T) {
+	chunks := Collect(arrString).Chunk(2)
+	assert.Equal(t, 3, chunks.Size())
+	assert.Equal(t, []interface{}{0, 1, 2}, chunks.Keys().All())
+	assert.Equal(t, map[interface{}]interface{}{0: "Hello", 1: "World"}, chunks.GetValue(0).(Collection).All())
+	assert.Equal(t, map[interface{}]interface{}{2: "Are", 3: "You"}, chunks.GetValue(1).(Collection).All())
+	assert.Equal(t, map[interface{}]interface{}{4: "Ready"}, chunks.GetValue(2).(Collection).All())
+
+	chunks = Collect(arrMap).Chunk(2)
+	assert.Equal(t, 2, chunks.Size())
+	assert.Equal(t, []interface{}{"Age", "First Name"}, chunks.GetValue(0).(Collection).Keys().All())
+	assert.Equal(t, []interface{}{"Last Name"}, chunks.GetValue(1).(Collection).Keys().All())
+
+	assert.Equal(t, 0, Collect(nil).Chunk(2).Size())
+	assert.PanicsWithValue(t, "the chunk size must be greater than zero", func() { Collect(arrString).Chunk(0) })
+	assert.PanicsWithValue(t, "the chunk size must be greater than zero", func() { Collect(arrString).Chunk(-1) })
+	assert.PanicsWithValue(t, "the chunk size must be greater than zero", func() { Collect(nil).Chunk(0) })
+}
+
+func TestCollectionSliding(t *testing.T) {
+	windows := Collect([]int{1, 2, 3, 4, 5}).Sliding(3, 1)
+	assert.Equal(t, 3, windows.Size())
+	assert.Equal(t, []interface{}{1, 2, 3}, windows.GetValue(0).(Collection).Values().All())
+	assert.Equal(t, []interface{}{2, 3, 4}, windows.GetValue(1).(Collection).Values().All())
+	assert.Equal(t, []interface{}{3, 4, 5}, windows.GetValue(2).(Collection).Values().All())
+	assert.Equal(t, []interface{}{2, 3, 4}, windows.GetValue(2).(Collection).Keys().All())
+
+	windows = Collect([]int{1, 2, 3, 4, 5}).Sliding(2, 2)
+	assert.Equal(t, 2, windows.Size())
+	assert.Equal(t, []interface{}{3, 4}, windows.GetValue(1).(Collection).Values().All())
+
+	assert.Equal(t, 0, Collect([]int{1, 2}).Sliding(3, 1).Size())
+	assert.PanicsWithValue(t, "the window size must be greater than zero", func() { Collect(arrString).Sliding(0, 1) })
+	assert.PanicsWithValue(t, "the window step must be greater than zero", func() { Collect(arrString).Sliding(2, 0) })
+}
+
+func TestCollectionChunkWhile(t *testing.T) {
+	chunks := Collect([]string{"A", "A", "B", "B", "C", "A"}).ChunkWhile(func(value interface{}, key interface{}, chunk Collection) bool {
+		return value == chunk.Values().Last()
+	})
+
+	assert.Equal(t, 4, chunks.Size())
+	assert.Equal(t, []interface{}{"A", "A"}, chunks.GetValue(0).(Collection).Values().All())
+	assert.Equal(t, []interface{}{"B", "B"}, chunks.GetValue(1).(Collection).Values().All())
+	assert.Equal(t, []interface{}{"C"}, chunks.GetValue(2).(Collection).Values().All())
+	assert.Equal(t, []interface{}{5}, chunks.GetValue(3).(Collection).Keys().All())
+	assert.Equal(t, 0, Collect(nil).ChunkWhile(func(value interface{}, key interface{}, chunk Collection) bool { return true }).Size())
+}
+
+func TestCollectionSplitIn(t *testing.T) {
+	groups := Collect([]int{1, 2, 3, 4, 5, 6, 7}).SplitIn(3)
+	assert.Equal(t, 3, groups.Size())
+	assert.Equal(t, []interface{}{1, 2, 3}, groups.GetValue(0).(Collection).Values().All())
+	assert.Equal(t, []interface{}{4, 5}, groups.GetValue(1).(Collection).Values().All())
+	assert.Equal(t, []interface{}{6, 7}, groups.GetValue(2).(Collection).Values().All())
+
+	groups = Collect([]int{1, 2}).SplitIn(3)
+	assert.Equal(t, 2, groups.Size())
+	assert.PanicsWithValue(t, "the number of groups must be greater than zero", func() { Collect(arrString).SplitIn(0) })
+}
+
+func TestCollectionPartition(t *testing.T) {
+	passed, failed := Collect([]int{1, 2, 3, 4, 5}).Partition(func(value interface{}, key interface{}, index int) bool {
+		return value.(int)%2 == 0
+	})
+
+	assert.Equal(t, map[interface{}]interface{}{1: 2, 3: 4}, passed.All())
+	assert.Equal(t, map[interface{}]interface{}{0: 1, 2: 3, 4: 5}, failed.All())
+
+	passed, failed = Collect(arrMap).Partition(func(value interface{}, key interface{}, index int) bool {
+		return key == "Age"
+	})
+
+	assert.Equal(t, []interface{}{"Age"}, passed.Keys().All())
+	assert.Equal(t, []interface{}{"First Name", "Last Name"}, failed.Keys().All())
+}
@@ -0,0 +1,124 @@
+// Command collect-repl is an interactive shell exploring a collection.
+//
+// Usage:
+//
+//	collect-repl [-i format] [file]
+//
+// The shell keeps a current collection. A line starting with a method of Collection calls it on the current one,
+// for example "Filter age >= 18", "GroupBy city", or "Only name,age". A result that is a collection becomes
+// the current one and can be undone, other results are printed. Type help for the commands and methods,
+// tab completes their names, and the arrow keys go through the history of lines.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/habibimustafa/collection/internal/cli"
+	"golang.org/x/term"
+	"io"
+	"os"
+	"strings"
+)
+
+func main() {
+	flags := flag.NewFlagSet("collect-repl", flag.ExitOnError)
+	input := flags.String("i", "", "the input format: "+strings.Join(cli.Formats, ", ")+", from the file extension when empty")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: collect-repl [flags] [file]")
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(os.Args[1:])
+
+	if err := run(*input, flags.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, "collect-repl:", err)
+		os.Exit(1)
+	}
+}
+
+func run(input string, args []string) error {
+	if len(args) > 1 {
+		return errors.New("only one file can be loaded at start")
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return script(input, args)
+	}
+
+	state, err := term.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		return err
+	}
+	defer term.Restore(int(os.Stdin.Fd()), state)
+
+	terminal := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{os.Stdin, os.Stdout}, "collect> ")
+	if width, height, err := term.GetSize(int(os.Stdout.Fd())); err == nil && width > 0 {
+		_ = terminal.SetSize(width, height)
+	}
+
+	session := cli.NewSession(terminal)
+	terminal.AutoCompleteCallback = func(line string, pos int, key rune) (string, int, bool) {
+		if key != '\t' {
+			return "", 0, false
+		}
+
+		newLine, newPos, candidates := session.Complete(line, pos)
+		if len(candidates) > 0 {
+			fmt.Fprintln(terminal, strings.Join(candidates, "  "))
+		}
+		return newLine, newPos, true
+	}
+
+	if err := load(session, input, args); err != nil {
+		fmt.Fprintln(terminal, "error:", err)
+	}
+
+	for {
+		line, err := terminal.ReadLine()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := session.Exec(line); errors.Is(err, cli.ErrQuit) {
+			return nil
+		} else if err != nil {
+			fmt.Fprintln(terminal, "error:", err)
+		}
+	}
+}
+
+// script runs the lines of a stdin that is not a terminal, stopping at the first error
+func script(input string, args []string) error {
+	session := cli.NewSession(os.Stdout)
+	if err := load(session, input, args); err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for line := 1; scanner.Scan(); line++ {
+		if err := session.Exec(scanner.Text()); errors.Is(err, cli.ErrQuit) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	return scanner.Err()
+}
+
+func load(session *cli.Session, input string, args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+
+	if input == "" {
+		input = cli.FormatOf(args[0])
+	}
+	return session.Load(args[0], input)
+}
@@ -0,0 +1,77 @@
+// Command collect reads a collection from a file or stdin, applies a chain of operations, and writes the result.
+//
+// Usage:
+//
+//	collect [-f file] [-i format] [-o format] [-pretty] [operation args...]...
+//
+// For example, the names of the adult users sorted by age:
+//
+//	collect -f users.csv -o json where age '>=' 18 sort-by age pluck name
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/habibimustafa/collection/internal/cli"
+	"io"
+	"os"
+	"strings"
+)
+
+func main() {
+	flags := flag.NewFlagSet("collect", flag.ExitOnError)
+	file := flags.String("f", "", "the file to read, stdin when empty")
+	input := flags.String("i", "", "the input format: "+strings.Join(cli.Formats, ", ")+", from the file extension or json when empty")
+	output := flags.String("o", "json", "the output format: "+strings.Join(cli.Formats, ", "))
+	pretty := flags.Bool("pretty", false, "indent JSON output")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: collect [flags] [operation args...]...")
+		flags.PrintDefaults()
+		fmt.Fprintln(flags.Output(), "operations:")
+		for _, operation := range cli.Operations() {
+			fmt.Fprintf(flags.Output(), "  %s\n    \t%s\n", strings.Join(append([]string{operation.Name}, operation.Args...), " "), operation.Usage)
+		}
+	}
+	_ = flags.Parse(os.Args[1:])
+
+	if err := run(*file, *input, *output, *pretty, flags.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, "collect:", err)
+		os.Exit(1)
+	}
+}
+
+func run(file string, input string, output string, pretty bool, args []string) error {
+	steps, err := cli.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	var r io.Reader = os.Stdin
+	if file != "" {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+
+		if input == "" {
+			input = cli.FormatOf(file)
+		}
+	}
+
+	if input == "" {
+		input = "json"
+	}
+
+	c, err := cli.Read(r, input)
+	if err != nil {
+		return err
+	}
+
+	result, err := cli.Run(c, steps)
+	if err != nil {
+		return err
+	}
+	return cli.Write(os.Stdout, result, output, pretty)
+}
@@ -2,8 +2,11 @@
 package collection
 
 import (
+	"errors"
 	"github.com/habibimustafa/collection/arr"
+	"github.com/habibimustafa/collection/deep"
 	"github.com/habibimustafa/collection/sort"
+	"math/rand"
 	"reflect"
 )
 
@@ -27,12 +30,40 @@ type Collection interface {
 	// GetValue gets value by key
 	GetValue(key interface{}) interface{}
 
-	// First gets the first item
+	// First gets the first item, it is empty when collection is empty
 	First() map[interface{}]interface{}
 
-	// Last gets the last item
+	// Last gets the last item, it is empty when collection is empty
 	Last() map[interface{}]interface{}
 
+	// FirstWhere gets the first item that passes the callback, it is empty when no item passes
+	FirstWhere(callback func(value interface{}, key interface{}, index int) bool) map[interface{}]interface{}
+
+	// LastWhere gets the last item that passes the callback, it is empty when no item passes
+	LastWhere(callback func(value interface{}, key interface{}, index int) bool) map[interface{}]interface{}
+
+	// FirstOr gets the first value, or the default value when collection is empty
+	FirstOr(defaultValue interface{}) interface{}
+
+	// Sole gets the only item that passes the callback,
+	// it fails when no item or more than one item passes
+	Sole(callback func(value interface{}, key interface{}, index int) bool) (map[interface{}]interface{}, error)
+
+	// Search gets the key of the first value deeply equal to the given value
+	Search(value interface{}) (interface{}, bool)
+
+	// SearchFunc gets the key of the first item that passes the callback
+	SearchFunc(callback func(value interface{}, key interface{}, index int) bool) (interface{}, bool)
+
+	// Every is every item passes the callback, it is true for an empty collection
+	Every(callback func(value interface{}, key interface{}, index int) bool) bool
+
+	// Some is at least one item passes the callback
+	Some(callback func(value interface{}, key interface{}, index int) bool) bool
+
+	// None is no item passes the callback
+	None(callback func(value interface{}, key interface{}, index int) bool) bool
+
 	// Slice gets slice of items
 	Slice(slice ...int) map[interface{}]interface{}
 
@@ -79,26 +110,224 @@ type Collection interface {
 	// Where alias of Filter method
 	Where(callback func(value interface{}, key interface{}, index int) bool) Collection
 
-	// When do callback when meet criteria
-	When(criteria func(collection Collection) bool, callback func(collection Collection) Collection) Collection
+	// When do callback when meet criteria, otherwise do the otherwise callback
+	When(criteria func(collection Collection) bool, callback func(collection Collection) Collection, otherwise ...func(collection Collection) Collection) Collection
+
+	// WhenBool do callback when ok is true, otherwise do the otherwise callback
+	WhenBool(ok bool, callback func(collection Collection) Collection, otherwise ...func(collection Collection) Collection) Collection
+
+	// WhenEmpty do callback when collection is empty, otherwise do the otherwise callback
+	WhenEmpty(callback func(collection Collection) Collection, otherwise ...func(collection Collection) Collection) Collection
+
+	// WhenNotEmpty do callback when collection is not empty, otherwise do the otherwise callback
+	WhenNotEmpty(callback func(collection Collection) Collection, otherwise ...func(collection Collection) Collection) Collection
+
+	// Unless do callback when not meet criteria, otherwise do the otherwise callback
+	Unless(criteria func(collection Collection) bool, callback func(collection Collection) Collection, otherwise ...func(collection Collection) Collection) Collection
+
+	// UnlessBool do callback when ok is false, otherwise do the otherwise callback
+	UnlessBool(ok bool, callback func(collection Collection) Collection, otherwise ...func(collection Collection) Collection) Collection
+
+	// UnlessEmpty do callback when collection is not empty, otherwise do the otherwise callback
+	UnlessEmpty(callback func(collection Collection) Collection, otherwise ...func(collection Collection) Collection) Collection
+
+	// WhenHas do callback when collection has all the keys, otherwise do the otherwise callback
+	WhenHas(keys []interface{}, callback func(collection Collection) Collection, otherwise ...func(collection Collection) Collection) Collection
+
+	// WhenContains do callback when collection contains key with value, otherwise do the otherwise callback
+	WhenContains(key interface{}, value interface{}, callback func(collection Collection) Collection, otherwise ...func(collection Collection) Collection) Collection
+
+	// Match starts a builder that does the callback of the first matching case
+	Match() *Matcher
+
+	// Chunk splits items into a collection of sub collections with the given size, the size must be greater than zero
+	Chunk(size int) Collection
+
+	// Sliding gets a collection of sliding windows with the given size and step
+	Sliding(size int, step int) Collection
+
+	// ChunkWhile splits items into sub collections while the callback returns true
+	ChunkWhile(callback func(value interface{}, key interface{}, chunk Collection) bool) Collection
+
+	// SplitIn splits items into the given number of balanced groups
+	SplitIn(groups int) Collection
+
+	// Partition separates items that pass the callback from the items that fail
+	Partition(callback func(value interface{}, key interface{}, index int) bool) (Collection, Collection)
+
+	// Equals is collection has the same keys and values in the same order
+	Equals(other Collection) bool
+
+	// EqualsIgnoreOrder is collection has the same keys and values in any order
+	EqualsIgnoreOrder(other Collection) bool
+
+	// DeepEquals is collection deeply equal to the other collection
+	DeepEquals(other Collection, options ...EqualOption) bool
+
+	// Hash gets a stable hash of the keys and values
+	Hash() uint64
+
+	// Unique removes items whose value repeats an earlier item
+	Unique() Collection
+
+	// UniqueBy removes items whose callback result repeats an earlier item
+	UniqueBy(callback func(value interface{}, key interface{}, index int) interface{}) Collection
+
+	// DedupeStrict removes items whose value repeats an earlier item with the same type
+	DedupeStrict() Collection
+
+	// Duplicates gets the keys of items whose value repeats an earlier item
+	Duplicates() arr.Array
+
+	// Zip merges the values of the other collections with the values at the same position
+	Zip(others ...Collection) Collection
+
+	// CrossJoin gets the cartesian product of the values with the values of the other collections
+	CrossJoin(others ...Collection) Collection
+
+	// Pad fills the collection with the value until it reaches the size
+	Pad(size int, value interface{}) Collection
+
+	// Shuffle gets the items in a random order decided by the source
+	Shuffle(source rand.Source) Collection
+
+	// Random gets n random values, the same item can be picked more than once
+	Random(source rand.Source, n int) Collection
+
+	// Sample gets n random items, each item can be picked only once
+	Sample(source rand.Source, n int) Collection
+
+	// WeightedSample gets n random items where items with larger weight are more likely to be picked
+	WeightedSample(source rand.Source, n int, weight func(value interface{}, key interface{}, index int) float64) Collection
+
+	// Validate checks the values at the dot paths of the rules
+	Validate(rules map[string]Rule) ValidationErrors
+
+	// Call runs the registered macro with the collection and arguments
+	Call(name string, args ...interface{}) Collection
+
+	// TryCall runs the registered macro, it returns an error when the macro is not registered
+	TryCall(name string, args ...interface{}) (Collection, error)
+
+	// Pipe passes the collection to the callback and returns the result
+	Pipe(callback func(collection Collection) Collection) Collection
+
+	// Frequencies counts how many times each value appears, the most frequent first
+	Frequencies() Collection
+
+	// Histogram counts the numeric values falling into the given number of equal width buckets
+	Histogram(buckets int) Collection
+
+	// TopN gets the n items with the largest callback results, largest first
+	TopN(n int, callback func(value interface{}, key interface{}, index int) interface{}) Collection
+
+	// BottomN gets the n items with the smallest callback results, smallest first
+	BottomN(n int, callback func(value interface{}, key interface{}, index int) interface{}) Collection
+
+	// Quantiles gets the k-quantiles of the numeric values, including the minimum and the maximum
+	Quantiles(k int) Collection
+
+	// Pivot reshapes a collection of rows from long to wide format
+	Pivot(rowKey string, columnKey string, valueKey string, aggregate func(values arr.Array) interface{}, fill ...interface{}) Collection
+
+	// Unpivot reshapes a collection of rows from wide to long format
+	Unpivot(idKeys []string, valueColumns []string) Collection
+
+	// Join combines the items of both collections whose join keys are equal
+	Join(other Collection, leftKey func(value interface{}, key interface{}, index int) interface{}, rightKey func(value interface{}, key interface{}, index int) interface{}, combine func(left interface{}, right interface{}) interface{}) Collection
+
+	// LeftJoin is like Join, but also keeps the items of this collection without a match
+	LeftJoin(other Collection, leftKey func(value interface{}, key interface{}, index int) interface{}, rightKey func(value interface{}, key interface{}, index int) interface{}, combine func(left interface{}, right interface{}) interface{}) Collection
+
+	// RightJoin is like Join, but also keeps the items of the other collection without a match
+	RightJoin(other Collection, leftKey func(value interface{}, key interface{}, index int) interface{}, rightKey func(value interface{}, key interface{}, index int) interface{}, combine func(left interface{}, right interface{}) interface{}) Collection
+
+	// FullJoin is like Join, but also keeps the items of both collections without a match
+	FullJoin(other Collection, leftKey func(value interface{}, key interface{}, index int) interface{}, rightKey func(value interface{}, key interface{}, index int) interface{}, combine func(left interface{}, right interface{}) interface{}) Collection
+
+	// GroupJoin combines each item with all the items of the other collection sharing its join key
+	GroupJoin(other Collection, leftKey func(value interface{}, key interface{}, index int) interface{}, rightKey func(value interface{}, key interface{}, index int) interface{}, combine func(left interface{}, rights arr.Array) interface{}) Collection
+
+	// Merge merges the other collections into the collection, the later values win on conflicting keys
+	Merge(others ...Collection) Collection
+
+	// MergeWith merges the other collections into the collection, resolving conflicting keys with the policy
+	MergeWith(policy ConflictPolicy, others ...Collection) (Collection, error)
+
+	// MergeRecursive is like Merge, but conflicting nested collections, maps, and slices are merged together
+	MergeRecursive(others ...Collection) Collection
+
+	// MergeRecursiveWith is like MergeWith, but conflicting nested collections, maps, and slices are merged together
+	MergeRecursiveWith(policy ConflictPolicy, others ...Collection) (Collection, error)
+
+	// Replace replaces the values with the values of the other collections under the same keys
+	Replace(others ...Collection) Collection
 
-	// WhenEmpty do callback when collection is empty
-	WhenEmpty(callback func(collection Collection) Collection) Collection
+	// ReplaceRecursive is like Replace, but nested collections, maps, and slices are replaced recursively
+	ReplaceRecursive(others ...Collection) Collection
 
-	// WhenNotEmpty do callback when collection is not empty
-	WhenNotEmpty(callback func(collection Collection) Collection) Collection
+	// Reindex resets the keys to the positions of the items
+	Reindex() Collection
+
+	// MapValues converts each value into a new value, the keys are kept
+	MapValues(callback func(value interface{}, key interface{}, index int) interface{}) Collection
+
+	// MapKeys converts each key into a new key, the values are kept
+	MapKeys(callback func(value interface{}, key interface{}, index int) interface{}) Collection
+
+	// Flip swaps the keys with their values
+	Flip() Collection
+
+	// MapWithKeys converts each item into the entries of the returned map
+	MapWithKeys(callback func(value interface{}, key interface{}, index int) map[interface{}]interface{}) Collection
+
+	// FlatMap converts each item into a collection, slice, or array and flattens the results by one level
+	FlatMap(callback func(value interface{}, key interface{}, index int) interface{}) Collection
+
+	// Dot flattens nested collections and maps into a single level collection with dot path keys
+	Dot() Collection
+
+	// Undot expands the string keys holding dots into nested collections
+	Undot() Collection
+
+	// ToEnv formats the collection as environment variables like "APP_DB_HOST=localhost"
+	ToEnv(prefix string) []string
+
+	// SortBy sorts the items by their callback results from the smallest
+	SortBy(callback func(value interface{}, key interface{}, index int) interface{}) Collection
+
+	// SortByDesc sorts the items by their callback results from the largest
+	SortByDesc(callback func(value interface{}, key interface{}, index int) interface{}) Collection
+
+	// GroupBy groups the items by their callback results into collections
+	GroupBy(callback func(value interface{}, key interface{}, index int) interface{}) Collection
+
+	// Pluck gets the values at the dot path inside each value
+	Pluck(path string) Collection
+
+	// Sum adds up the numeric values
+	Sum() float64
+
+	// MarshalJSON encodes the collection as a JSON object keeping the order of the items
+	MarshalJSON() ([]byte, error)
 }
 
 // collect define a structure of array, slice, or map
 type collect struct {
-	keys   []interface{}
-	values []interface{}
+	keys    []interface{}
+	values  []interface{}
+	equaler KeyEqualer
 }
 
 // Collect collecting an array, slice, or map as a Collection object
-func Collect(collection interface{}) Collection {
+func Collect(collection interface{}, options ...Option) Collection {
+	c := collect{}
+	for _, option := range options {
+		option(&c)
+	}
+
 	if collection == nil {
-		return collect{}
+		return c
 	}
 
 	val := reflect.ValueOf(collection)
@@ -110,7 +339,7 @@ func Collect(collection interface{}) Collection {
 			keys = append(keys, i)
 			values = append(values, val.Index(i).Interface())
 		}
-		return collect{keys: keys, values: values}
+		return c.collect(keys, values)
 	case reflect.Map:
 		sorted := sort.Sort(val)
 
@@ -124,13 +353,30 @@ func Collect(collection interface{}) Collection {
 			values = append(values, v.Interface())
 		}
 
-		return collect{keys: keys, values: values}
+		return c.collect(keys, values)
 	default:
 		panic("collection: collection type must be a slice, array, map, or nil")
-		return nil
 	}
 }
 
+// collect fills the collection with the items, keys that the key equaler treats as equal are merged
+// into the first of them and keep the last value
+func (c collect) collect(keys []interface{}, values []interface{}) collect {
+	if c.equaler == nil {
+		return c.with(keys, values)
+	}
+
+	b := c.build()
+	for i, key := range keys {
+		if index := b.indexOf(key); index > -1 {
+			b.values[index] = values[i]
+			continue
+		}
+		b.add(key, values[i])
+	}
+	return b.collect
+}
+
 // Size count the collection items
 func (c collect) Size() int {
 	return len(c.keys)
@@ -174,7 +420,7 @@ func (c collect) Get(index int) map[interface{}]interface{} {
 
 // GetValue gets value by key
 func (c collect) GetValue(key interface{}) interface{} {
-	index := c.Keys().Index(key)
+	index := c.indexOf(key)
 	if index > -1 {
 		return c.Values().Get(index)
 	}
@@ -182,13 +428,19 @@ func (c collect) GetValue(key interface{}) interface{} {
 	return nil
 }
 
-// First gets the first item
+// First gets the first item, it is empty when collection is empty
 func (c collect) First() map[interface{}]interface{} {
+	if c.Empty() {
+		return map[interface{}]interface{}{}
+	}
 	return c.Get(0)
 }
 
-// Last gets the last item
+// Last gets the last item, it is empty when collection is empty
 func (c collect) Last() map[interface{}]interface{} {
+	if c.Empty() {
+		return map[interface{}]interface{}{}
+	}
 	return c.Get(c.Size() - 1)
 }
 
@@ -215,7 +467,8 @@ func (c collect) Slice(slice ...int) map[interface{}]interface{} {
 
 // Contains is collection contains key with value
 func (c collect) Contains(key interface{}, value interface{}) bool {
-	return c.All()[key] == value
+	index := c.indexOf(key)
+	return index > -1 && deep.Equal(c.values[index], value)
 }
 
 // Has is collection has provided keys
@@ -224,8 +477,13 @@ func (c collect) Has(keys ...interface{}) bool {
 		return false
 	}
 
+	if len(keys) == 1 {
+		return c.indexOf(keys[0]) > -1
+	}
+
+	b := c.build()
 	for _, k := range keys {
-		if !c.Keys().Has(k) {
+		if b.indexOf(k) < 0 {
 			return false
 		}
 	}
@@ -236,47 +494,38 @@ func (c collect) Has(keys ...interface{}) bool {
 // Append add new item to last position
 func (c collect) Append(key interface{}, value interface{}) Collection {
 	c.validateKey(key)
-	return collect{
-		keys:   c.Keys().Append(key).All(),
-		values: c.Values().Append(value).All(),
-	}
+	return c.with(c.Keys().Append(key).All(), c.Values().Append(value).All())
 }
 
 // Prepend add new item to first position
 func (c collect) Prepend(key interface{}, value interface{}) Collection {
 	c.validateKey(key)
-	return collect{
-		keys:   c.Keys().Prepend(key).All(),
-		values: c.Values().Prepend(value).All(),
-	}
+	return c.with(c.Keys().Prepend(key).All(), c.Values().Prepend(value).All())
 }
 
 // Set update the existing item when its exist
 // when not exist, it will add new item to last position
 func (c collect) Set(key interface{}, value interface{}) Collection {
-	if !c.Keys().Has(key) {
+	index := c.indexOf(key)
+	if index < 0 {
 		return c.Append(key, value)
 	}
 
-	index := c.Keys().Index(key)
-	values := c.Values().All()
+	values := append([]interface{}{}, c.values...)
 	values[index] = value
 
-	return collect{
-		keys:   c.Keys().All(),
-		values: values,
-	}
+	return c.with(c.Keys().All(), values)
 }
 
 // Unset remove item by key
 func (c collect) Unset(key interface{}) Collection {
-	if !c.Keys().Has(key) {
+	removed := c.indexOf(key)
+	if removed < 0 {
 		panic("the inputted key is not exist in this collection")
 	}
 
-	removedKey := key
 	return c.Filter(func(value interface{}, key interface{}, index int) bool {
-		return key != removedKey
+		return index != removed
 	})
 }
 
@@ -287,15 +536,17 @@ func (c collect) Remove(key interface{}) Collection {
 
 // Except gets all items except provided keys
 func (c collect) Except(keys ...interface{}) Collection {
+	matches := c.matcher(keys)
 	return c.Filter(func(value interface{}, key interface{}, index int) bool {
-		return !arr.List(keys).Has(key)
+		return !matches(key)
 	})
 }
 
 // Only gets all items that match with provided keys
 func (c collect) Only(keys ...interface{}) Collection {
+	matches := c.matcher(keys)
 	return c.Filter(func(value interface{}, key interface{}, index int) bool {
-		return arr.List(keys).Has(key)
+		return matches(key)
 	})
 }
 
@@ -316,7 +567,7 @@ func (c collect) Map(callback func(value interface{}, key interface{}, index int
 		values = append(values, newValue)
 		keys = append(keys, newKey)
 	}
-	return collect{keys: keys, values: values}
+	return c.with(keys, values)
 }
 
 // Tap Pass the collection to the given callback and then return it.
@@ -338,7 +589,7 @@ func (c collect) Filter(callback func(value interface{}, key interface{}, index
 		keys = append(keys, c.Keys().Get(i))
 	}
 
-	return collect{keys: keys, values: values}
+	return c.with(keys, values)
 }
 
 // Where alias of Filter method
@@ -346,43 +597,68 @@ func (c collect) Where(callback func(value interface{}, key interface{}, index i
 	return c.Filter(callback)
 }
 
-// When do callback when meet criteria
-func (c collect) When(criteria func(collection Collection) bool, callback func(collection Collection) Collection) Collection {
-	if criteria(c) {
-		return callback(c)
-	}
+// When do callback when meet criteria, otherwise do the otherwise callback
+func (c collect) When(criteria func(collection Collection) bool, callback func(collection Collection) Collection, otherwise ...func(collection Collection) Collection) Collection {
+	return c.branch(criteria(c), callback, otherwise)
+}
 
-	return c
+// WhenBool do callback when ok is true, otherwise do the otherwise callback
+func (c collect) WhenBool(ok bool, callback func(collection Collection) Collection, otherwise ...func(collection Collection) Collection) Collection {
+	return c.branch(ok, callback, otherwise)
 }
 
-// WhenEmpty do callback when collection is empty
-func (c collect) WhenEmpty(callback func(collection Collection) Collection) Collection {
-	if c.Empty() {
-		return callback(c)
-	}
+// WhenEmpty do callback when collection is empty, otherwise do the otherwise callback
+func (c collect) WhenEmpty(callback func(collection Collection) Collection, otherwise ...func(collection Collection) Collection) Collection {
+	return c.branch(c.Empty(), callback, otherwise)
+}
 
-	return c
+// WhenNotEmpty do callback when collection is not empty, otherwise do the otherwise callback
+func (c collect) WhenNotEmpty(callback func(collection Collection) Collection, otherwise ...func(collection Collection) Collection) Collection {
+	return c.branch(c.NotEmpty(), callback, otherwise)
 }
 
-// WhenNotEmpty do callback when collection is not empty
-func (c collect) WhenNotEmpty(callback func(collection Collection) Collection) Collection {
-	if c.NotEmpty() {
-		return callback(c)
+func (c collect) validateKey(key interface{}) {
+	if err := c.checkKey(key); err != nil {
+		panic(err.Error())
 	}
+}
 
-	return c
+// checkKey reports why the key cannot be added, it is nil when the key can be added
+func (c collect) checkKey(key interface{}) error {
+	if err := c.checkKeyType(key); err != nil {
+		return err
+	}
+
+	if c.indexOf(key) > -1 {
+		return errKeyExists
+	}
+	return nil
 }
 
-func (c collect) validateKey(key interface{}) {
-	if c.Keys().Has(key) {
-		panic("the new key is already exists")
+// checkKeyType reports why the key cannot be added next to the existing keys, whether or not it exists
+func (c collect) checkKeyType(key interface{}) error {
+	if key == nil {
+		return errKeyNil
 	}
 
-	if c.Keys().Size() > 0 {
-		keyType := reflect.TypeOf(c.Keys().First()).Kind()
-		newKeyType := reflect.TypeOf(key).Kind()
-		if keyType != newKeyType {
-			panic("the new key type is different")
-		}
+	if c.equaler != nil {
+		return nil
+	}
+
+	if !reflect.TypeOf(key).Comparable() {
+		return errKeyUncomparable
 	}
+
+	if len(c.keys) > 0 && reflect.TypeOf(c.keys[0]).Kind() != reflect.TypeOf(key).Kind() {
+		return errKeyType
+	}
+	return nil
 }
+
+var errKeyNil = errors.New("the new key must not be nil")
+
+var errKeyUncomparable = errors.New("the new key is not comparable, use a key equaler such as DeepKeys")
+
+var errKeyExists = errors.New("the new key is already exists")
+
+var errKeyType = errors.New("the new key type is different")
@@ -78,6 +78,7 @@ func TestCollectionAppend(t *testing.T) {
 	assert.Equal(t, map[interface{}]interface{}{"City": "Westview"}, appended.Last())
 	assert.PanicsWithValue(t, "the new key is already exists", func() { mapCollection.Append("Age", 18) })
 	assert.PanicsWithValue(t, "the new key type is different", func() { mapCollection.Append('a', 18) })
+	assert.PanicsWithValue(t, "the new key must not be nil", func() { mapCollection.Append(nil, 18) })
 	assert.NotPanics(t, func() { mapCollection.Append("Blood-type", 'O') })
 }
 
@@ -123,6 +124,15 @@ func TestCollectionSet(t *testing.T) {
 	assert.PanicsWithValue(t, "the new key type is different", func() { mapCollection.Set('a', 18) })
 }
 
+func TestCollectionNilKey(t *testing.T) {
+	assert.PanicsWithValue(t, "the new key must not be nil", func() { Collect(arrMap).Append(nil, 18) })
+	assert.PanicsWithValue(t, "the new key must not be nil", func() { Collect(arrMap).Prepend(nil, 18) })
+	assert.PanicsWithValue(t, "the new key must not be nil", func() { Collect(arrMap).Set(nil, 18) })
+	assert.PanicsWithValue(t, "the new key must not be nil", func() { Collect(nil).Append(nil, 18) })
+	assert.PanicsWithValue(t, "the new key must not be nil", func() { Collect(nil, WithKeyEqualer(DeepKeys{})).Append(nil, 18) })
+	assert.False(t, Collect(arrMap).Has(nil))
+}
+
 func TestCollectionUnset(t *testing.T) {
 	unset := Collect(arrString).Unset(2)
 	assert.Equal(t, 4, unset.Size())
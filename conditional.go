@@ -0,0 +1,87 @@
+package collection
+
+// Unless do callback when not meet criteria, otherwise do the otherwise callback
+func (c collect) Unless(criteria func(collection Collection) bool, callback func(collection Collection) Collection, otherwise ...func(collection Collection) Collection) Collection {
+	return c.branch(!criteria(c), callback, otherwise)
+}
+
+// UnlessBool do callback when ok is false, otherwise do the otherwise callback
+func (c collect) UnlessBool(ok bool, callback func(collection Collection) Collection, otherwise ...func(collection Collection) Collection) Collection {
+	return c.branch(!ok, callback, otherwise)
+}
+
+// UnlessEmpty do callback when collection is not empty, otherwise do the otherwise callback
+func (c collect) UnlessEmpty(callback func(collection Collection) Collection, otherwise ...func(collection Collection) Collection) Collection {
+	return c.branch(!c.Empty(), callback, otherwise)
+}
+
+// WhenHas do callback when collection has all the keys, otherwise do the otherwise callback
+func (c collect) WhenHas(keys []interface{}, callback func(collection Collection) Collection, otherwise ...func(collection Collection) Collection) Collection {
+	return c.branch(c.Has(keys...), callback, otherwise)
+}
+
+// WhenContains do callback when collection contains key with value, otherwise do the otherwise callback
+func (c collect) WhenContains(key interface{}, value interface{}, callback func(collection Collection) Collection, otherwise ...func(collection Collection) Collection) Collection {
+	return c.branch(c.Contains(key, value), callback, otherwise)
+}
+
+// Match starts a builder that does the callback of the first matching case
+func (c collect) Match() *Matcher {
+	return &Matcher{collection: c}
+}
+
+// Matcher does the callback of the first case whose criteria the collection meets
+type Matcher struct {
+	collection collect
+	matched    bool
+	result     Collection
+}
+
+// Case adds a case that matches when the collection meets the criteria
+func (m *Matcher) Case(criteria func(collection Collection) bool, callback func(collection Collection) Collection) *Matcher {
+	if !m.matched && criteria(m.collection) {
+		m.matched, m.result = true, callback(m.collection)
+	}
+	return m
+}
+
+// CaseBool adds a case that matches when ok is true
+func (m *Matcher) CaseBool(ok bool, callback func(collection Collection) Collection) *Matcher {
+	if !m.matched && ok {
+		m.matched, m.result = true, callback(m.collection)
+	}
+	return m
+}
+
+// Default does the callback when no case matched and returns the result
+func (m *Matcher) Default(callback func(collection Collection) Collection) Collection {
+	if !m.matched {
+		return callback(m.collection)
+	}
+	return m.result
+}
+
+// End returns the result of the matching case, or the collection when no case matched
+func (m *Matcher) End() Collection {
+	if !m.matched {
+		return m.collection
+	}
+	return m.result
+}
+
+// branch do callback when ok, otherwise do the otherwise callback when given
+func (c collect) branch(ok bool, callback func(collection Collection) Collection, otherwise []func(collection Collection) Collection) Collection {
+	if len(otherwise) > 1 {
+		panic("only one otherwise callback is allowed")
+	}
+
+	if ok {
+		return callback(c)
+	}
+
+	if len(otherwise) == 1 {
+		return otherwise[0](c)
+	}
+
+	return c
+}
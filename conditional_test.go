@@ -0,0 +1,93 @@
+package collection
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func appendHaha(c Collection) Collection {
+	return c.Append(20, "Haha")
+}
+
+func appendHihi(c Collection) Collection {
+	return c.Append(30, "Hihi")
+}
+
+func TestCollectionWhenOtherwise(t *testing.T) {
+	c := Collect(arrString).WhenBool(true, appendHaha, appendHihi)
+	d := Collect(arrString).WhenBool(false, appendHaha, appendHihi)
+	e := Collect(arrString).When(func(c Collection) bool { return c.Size() > 10 }, appendHaha, appendHihi)
+
+	assert.True(t, c.Contains(20, "Haha"))
+	assert.False(t, c.Has(30))
+	assert.True(t, d.Contains(30, "Hihi"))
+	assert.False(t, d.Has(20))
+	assert.True(t, e.Contains(30, "Hihi"))
+
+	assert.True(t, Collect(nil).WhenEmpty(appendHaha, appendHihi).Has(20))
+	assert.True(t, Collect(arrString).WhenEmpty(appendHaha, appendHihi).Has(30))
+	assert.True(t, Collect(nil).WhenNotEmpty(appendHaha, appendHihi).Has(30))
+
+	assert.PanicsWithValue(t, "only one otherwise callback is allowed", func() { Collect(arrString).WhenBool(true, appendHaha, appendHihi, appendHihi) })
+}
+
+func TestCollectionWhenNamedCriteria(t *testing.T) {
+	type sizeCheck func(collection Collection) bool
+	var small sizeCheck = func(c Collection) bool { return c.Size() < 10 }
+
+	assert.True(t, Collect(arrString).When(small, appendHaha).Has(20))
+	assert.True(t, Collect(arrString).Unless(small, appendHaha, appendHihi).Has(30))
+	assert.True(t, Collect(arrString).Match().Case(small, appendHaha).End().Has(20))
+}
+
+func TestCollectionUnless(t *testing.T) {
+	assert.True(t, Collect(arrString).UnlessBool(false, appendHaha).Has(20))
+	assert.False(t, Collect(arrString).UnlessBool(true, appendHaha).Has(20))
+	assert.True(t, Collect(arrString).Unless(func(c Collection) bool { return c.Has(0) }, appendHaha, appendHihi).Has(30))
+
+	assert.True(t, Collect(arrString).UnlessEmpty(appendHaha, appendHihi).Has(20))
+	assert.True(t, Collect(nil).UnlessEmpty(appendHaha, appendHihi).Has(30))
+}
+
+func TestCollectionWhenHas(t *testing.T) {
+	assert.True(t, Collect(arrString).WhenHas([]interface{}{0, 4}, appendHaha, appendHihi).Has(20))
+	assert.True(t, Collect(arrString).WhenHas([]interface{}{0, 5}, appendHaha, appendHihi).Has(30))
+	assert.False(t, Collect(arrString).WhenHas([]interface{}{5}, appendHaha).Has(20))
+}
+
+func TestCollectionWhenContains(t *testing.T) {
+	assert.True(t, Collect(arrString).WhenContains(0, "Hello", appendHaha, appendHihi).Has(20))
+	assert.True(t, Collect(arrString).WhenContains(0, "World", appendHaha, appendHihi).Has(30))
+}
+
+func TestCollectionMatch(t *testing.T) {
+	size := func(c Collection) Collection {
+		return c.Match().
+			CaseBool(c.Size() == 0, func(c Collection) Collection { return Collect([]string{"empty"}) }).
+			Case(func(c Collection) bool { return c.Size() < 3 }, func(c Collection) Collection { return Collect([]string{"small"}) }).
+			Case(func(c Collection) bool { return c.Size() < 5 }, func(c Collection) Collection { return Collect([]string{"medium"}) }).
+			Default(func(c Collection) Collection { return Collect([]string{"large"}) })
+	}
+
+	assert.Equal(t, "empty", size(Collect(nil)).GetValue(0))
+	assert.Equal(t, "small", size(Collect([]int{1})).GetValue(0))
+	assert.Equal(t, "medium", size(Collect([]int{1, 2, 3})).GetValue(0))
+	assert.Equal(t, "large", size(Collect(arrString)).GetValue(0))
+
+	c := Collect(arrString).Match().CaseBool(false, appendHaha).End()
+	assert.True(t, c.Equals(Collect(arrString)))
+
+	c = Collect(arrString).Match().CaseBool(true, appendHaha).CaseBool(true, appendHihi).End()
+	assert.True(t, c.Has(20))
+	assert.False(t, c.Has(30))
+
+	calls := 0
+	none := func(c Collection) Collection {
+		calls++
+		return nil
+	}
+	c = Collect(arrString).Match().CaseBool(true, none).CaseBool(true, none).Default(none)
+	assert.Nil(t, c)
+	assert.Equal(t, 1, calls)
+	assert.Nil(t, Collect(arrString).Match().Case(func(c Collection) bool { return true }, none).End())
+}
@@ -0,0 +1,349 @@
+package collection
+
+import (
+	"fmt"
+	"github.com/habibimustafa/collection/arr"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// DecodeError reports the keys and fields that could not be matched while decoding,
+// the matched fields are still decoded
+type DecodeError struct {
+	// Unknown is the paths of the keys without a matching field
+	Unknown []string
+
+	// Missing is the paths of the fields without a matching key
+	Missing []string
+}
+
+// Error lists the unknown keys and the missing fields
+func (e *DecodeError) Error() string {
+	var problems []string
+	if len(e.Unknown) > 0 {
+		problems = append(problems, "unknown keys "+strings.Join(e.Unknown, ", "))
+	}
+	if len(e.Missing) > 0 {
+		problems = append(problems, "missing fields "+strings.Join(e.Missing, ", "))
+	}
+	return "collection: " + strings.Join(problems, "; ")
+}
+
+// Decode fills the value pointed by out with the collection items.
+//
+// Structs are matched by the `collection` tag, then the `json` tag, then the field name,
+// names are compared case-insensitively when there is no exact match. Fields tagged
+// with omitempty are optional. Nested collections, maps, slices, and arrays are decoded
+// into structs, maps, slices, and arrays, and numbers are converted between kinds
+// as long as the value fits. Unknown keys and missing fields are reported with a *DecodeError.
+func Decode(collection Collection, out interface{}) error {
+	val := reflect.ValueOf(out)
+	if val.Kind() != reflect.Ptr || val.IsNil() {
+		return fmt.Errorf("collection: decode target must be a non-nil pointer, got %T", out)
+	}
+
+	d := &decoder{}
+	if err := d.decode("", collection, val.Elem()); err != nil {
+		return err
+	}
+
+	if len(d.unknown) > 0 || len(d.missing) > 0 {
+		return &DecodeError{Unknown: d.unknown, Missing: d.missing}
+	}
+	return nil
+}
+
+type decoder struct {
+	unknown []string
+	missing []string
+}
+
+func (d *decoder) decode(path string, src interface{}, dst reflect.Value) error {
+	if src == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Ptr:
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return d.decode(path, src, dst.Elem())
+	case reflect.Interface:
+		val := reflect.ValueOf(src)
+		if !val.Type().AssignableTo(dst.Type()) {
+			return d.mismatch(path, src, dst)
+		}
+		dst.Set(val)
+		return nil
+	case reflect.Struct:
+		if _, ok := src.(Collection); !ok && reflect.TypeOf(src).AssignableTo(dst.Type()) {
+			dst.Set(reflect.ValueOf(src))
+			return nil
+		}
+		return d.decodeStruct(path, src, dst)
+	case reflect.Map:
+		return d.decodeMap(path, src, dst)
+	case reflect.Slice, reflect.Array:
+		return d.decodeList(path, src, dst)
+	case reflect.Bool:
+		val := reflect.ValueOf(src)
+		if val.Kind() != reflect.Bool {
+			return d.mismatch(path, src, dst)
+		}
+		dst.SetBool(val.Bool())
+		return nil
+	case reflect.String:
+		val := reflect.ValueOf(src)
+		if val.Kind() != reflect.String {
+			return d.mismatch(path, src, dst)
+		}
+		dst.SetString(val.String())
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := toInt(src)
+		if !ok || dst.OverflowInt(n) {
+			return d.mismatch(path, src, dst)
+		}
+		dst.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, ok := toUint(src)
+		if !ok || dst.OverflowUint(n) {
+			return d.mismatch(path, src, dst)
+		}
+		dst.SetUint(n)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		n, ok := toFloat(src)
+		if !ok || dst.OverflowFloat(n) {
+			return d.mismatch(path, src, dst)
+		}
+		dst.SetFloat(n)
+		return nil
+	default:
+		val := reflect.ValueOf(src)
+		if !val.Type().AssignableTo(dst.Type()) {
+			return d.mismatch(path, src, dst)
+		}
+		dst.Set(val)
+		return nil
+	}
+}
+
+func (d *decoder) decodeStruct(path string, src interface{}, dst reflect.Value) error {
+	keys, values, ok := entries(src)
+	if !ok {
+		return d.mismatch(path, src, dst)
+	}
+
+	used := make([]bool, len(keys))
+	for _, field := range fieldsOf(dst.Type()) {
+		index := -1
+		for i, key := range keys {
+			if !used[i] && fmt.Sprint(key) == field.name {
+				index = i
+				break
+			}
+		}
+
+		if index < 0 {
+			for i, key := range keys {
+				if !used[i] && strings.EqualFold(fmt.Sprint(key), field.name) {
+					index = i
+					break
+				}
+			}
+		}
+
+		if index < 0 {
+			if !field.optional {
+				d.missing = append(d.missing, joinPath(path, field.name))
+			}
+			continue
+		}
+
+		used[index] = true
+		if err := d.decode(joinPath(path, field.name), values[index], fieldByIndex(dst, field.index)); err != nil {
+			return err
+		}
+	}
+
+	for i, key := range keys {
+		if !used[i] {
+			d.unknown = append(d.unknown, joinPath(path, fmt.Sprint(key)))
+		}
+	}
+	return nil
+}
+
+func (d *decoder) decodeMap(path string, src interface{}, dst reflect.Value) error {
+	keys, values, ok := entries(src)
+	if !ok {
+		return d.mismatch(path, src, dst)
+	}
+
+	m := reflect.MakeMapWithSize(dst.Type(), len(keys))
+	for i, key := range keys {
+		k := reflect.New(dst.Type().Key()).Elem()
+		if err := d.decode(joinPath(path, fmt.Sprint(key)), key, k); err != nil {
+			return err
+		}
+
+		v := reflect.New(dst.Type().Elem()).Elem()
+		if err := d.decode(joinPath(path, fmt.Sprint(key)), values[i], v); err != nil {
+			return err
+		}
+		m.SetMapIndex(k, v)
+	}
+
+	dst.Set(m)
+	return nil
+}
+
+func (d *decoder) decodeList(path string, src interface{}, dst reflect.Value) error {
+	var items []interface{}
+	if c, ok := src.(Collection); ok {
+		items = c.Values().All()
+	} else if val := reflect.ValueOf(src); val.Kind() == reflect.Slice || val.Kind() == reflect.Array {
+		items = arr.List(src).All()
+	} else {
+		return d.mismatch(path, src, dst)
+	}
+
+	if dst.Kind() == reflect.Array {
+		if len(items) > dst.Len() {
+			return fmt.Errorf("collection: cannot decode %d items into %s at %q", len(items), dst.Type(), path)
+		}
+		dst.Set(reflect.Zero(dst.Type()))
+	} else {
+		dst.Set(reflect.MakeSlice(dst.Type(), len(items), len(items)))
+	}
+
+	for i, item := range items {
+		if err := d.decode(joinPath(path, fmt.Sprint(i)), item, dst.Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *decoder) mismatch(path string, src interface{}, dst reflect.Value) error {
+	if path == "" {
+		return fmt.Errorf("collection: cannot decode %T into %s", src, dst.Type())
+	}
+	return fmt.Errorf("collection: cannot decode %T into %s at %q", src, dst.Type(), path)
+}
+
+// toInt converts a number into an int64 when it is integral and fits
+func toInt(src interface{}) (int64, bool) {
+	val := reflect.ValueOf(src)
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return val.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return int64(val.Uint()), val.Uint() <= math.MaxInt64
+	case reflect.Float32, reflect.Float64:
+		f := val.Float()
+		return int64(f), f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64
+	}
+	return 0, false
+}
+
+// toUint converts a number into an uint64 when it is integral and not negative
+func toUint(src interface{}) (uint64, bool) {
+	val := reflect.ValueOf(src)
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return uint64(val.Int()), val.Int() >= 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return val.Uint(), true
+	case reflect.Float32, reflect.Float64:
+		f := val.Float()
+		return uint64(f), f == math.Trunc(f) && f >= 0 && f < math.MaxUint64
+	}
+	return 0, false
+}
+
+// entries gets the keys and values of a collection or map in collection order
+func entries(src interface{}) ([]interface{}, []interface{}, bool) {
+	c, ok := src.(Collection)
+	if !ok {
+		if reflect.ValueOf(src).Kind() != reflect.Map {
+			return nil, nil, false
+		}
+		c = Collect(src)
+	}
+	return c.Keys().All(), c.Values().All(), true
+}
+
+type field struct {
+	name     string
+	index    []int
+	optional bool
+}
+
+// fieldsOf lists the decodable fields of a struct type, fields of embedded structs are promoted
+func fieldsOf(t reflect.Type) []field {
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, ok := f.Tag.Lookup("collection")
+		if !ok {
+			tag = f.Tag.Get("json")
+		}
+
+		name, options := tag, ""
+		if comma := strings.Index(tag, ","); comma > -1 {
+			name, options = tag[:comma], tag[comma+1:]
+		}
+
+		if name == "-" || (f.PkgPath != "" && !f.Anonymous) {
+			continue
+		}
+
+		embedded := f.Type
+		if embedded.Kind() == reflect.Ptr {
+			embedded = embedded.Elem()
+		}
+
+		if f.Anonymous && name == "" && embedded.Kind() == reflect.Struct && (f.PkgPath == "" || f.Type.Kind() != reflect.Ptr) {
+			for _, promoted := range fieldsOf(embedded) {
+				promoted.index = append([]int{i}, promoted.index...)
+				fields = append(fields, promoted)
+			}
+			continue
+		}
+
+		if f.PkgPath != "" {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		fields = append(fields, field{
+			name:     name,
+			index:    []int{i},
+			optional: strings.Contains(","+options+",", ",omitempty,"),
+		})
+	}
+	return fields
+}
+
+// fieldByIndex gets the nested field, allocating nil embedded pointers on the way
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
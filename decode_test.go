@@ -0,0 +1,107 @@
+package collection
+
+import (
+	"github.com/habibimustafa/collection/arr"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type address struct {
+	City string `collection:"city"`
+	Zip  *int   `json:"zip,omitempty"`
+}
+
+type Audit struct {
+	CreatedBy string `json:"created_by"`
+}
+
+type person struct {
+	Audit
+	Name    string            `collection:"name"`
+	Age     uint8             `json:"age"`
+	Score   float32           `json:"score"`
+	Active  bool              `json:"active"`
+	Tags    []string          `json:"tags"`
+	Address address           `json:"address"`
+	Labels  map[string]int64  `json:"labels"`
+	Extra   interface{}       `json:"extra,omitempty"`
+	Pair    [2]int            `json:"pair,omitempty"`
+	Ignored string            `json:"-"`
+	Others  []address         `json:"others,omitempty"`
+	Meta    map[string]string `collection:"meta,omitempty"`
+}
+
+func TestDecode(t *testing.T) {
+	zip := 12345
+	c := Collect(map[string]interface{}{
+		"name":       "John",
+		"Age":        28.0,
+		"score":      int64(99),
+		"active":     true,
+		"tags":       arr.Array{"a", "b"},
+		"labels":     map[string]interface{}{"x": 1, "y": 2.0},
+		"extra":      []int{1},
+		"pair":       []float64{1, 2},
+		"created_by": "admin",
+		"others":     []interface{}{map[string]interface{}{"city": "Eastview"}},
+	}).Append("address", Collect(map[string]interface{}{"city": "Westview", "zip": zip}))
+
+	var p person
+	assert.NoError(t, Decode(c, &p))
+	assert.Equal(t, "John", p.Name)
+	assert.Equal(t, uint8(28), p.Age)
+	assert.Equal(t, float32(99), p.Score)
+	assert.True(t, p.Active)
+	assert.Equal(t, []string{"a", "b"}, p.Tags)
+	assert.Equal(t, "Westview", p.Address.City)
+	assert.Equal(t, &zip, p.Address.Zip)
+	assert.Equal(t, map[string]int64{"x": 1, "y": 2}, p.Labels)
+	assert.Equal(t, []int{1}, p.Extra)
+	assert.Equal(t, [2]int{1, 2}, p.Pair)
+	assert.Equal(t, "admin", p.CreatedBy)
+	assert.Equal(t, []address{{City: "Eastview"}}, p.Others)
+}
+
+func TestDecodeUnknownAndMissing(t *testing.T) {
+	c := Collect(map[string]interface{}{"name": "John", "nickname": "Jo"}).
+		Append("address", Collect(map[string]interface{}{"country": "US"}))
+
+	var p person
+	err := Decode(c, &p)
+	assert.IsType(t, &DecodeError{}, err)
+	assert.Equal(t, []string{"address.country", "nickname"}, err.(*DecodeError).Unknown)
+	assert.Equal(t, []string{"created_by", "age", "score", "active", "tags", "address.city", "labels"}, err.(*DecodeError).Missing)
+	assert.EqualError(t, err, "collection: unknown keys address.country, nickname; "+
+		"missing fields created_by, age, score, active, tags, address.city, labels")
+	assert.Equal(t, "John", p.Name)
+}
+
+func TestDecodeConversionErrors(t *testing.T) {
+	var p person
+	assert.EqualError(t, Decode(Collect(map[string]interface{}{"age": 300}), &p), `collection: cannot decode int into uint8 at "age"`)
+	assert.EqualError(t, Decode(Collect(map[string]interface{}{"age": 2.5}), &p), `collection: cannot decode float64 into uint8 at "age"`)
+	assert.EqualError(t, Decode(Collect(map[string]interface{}{"age": -1}), &p), `collection: cannot decode int into uint8 at "age"`)
+	assert.EqualError(t, Decode(Collect(map[string]interface{}{"tags": "a"}), &p), `collection: cannot decode string into []string at "tags"`)
+	assert.EqualError(t, Decode(Collect(map[string]interface{}{"pair": []int{1, 2, 3}}), &p), `collection: cannot decode 3 items into [2]int at "pair"`)
+	assert.EqualError(t, Decode(Collect(map[string]interface{}{"labels": map[string]string{"x": "1"}}), &p), `collection: cannot decode string into int64 at "labels.x"`)
+	assert.EqualError(t, Decode(Collect(nil), p), "collection: decode target must be a non-nil pointer, got collection.person")
+}
+
+func TestDecodeSlicesAndMaps(t *testing.T) {
+	var numbers []int
+	assert.NoError(t, Decode(Collect([]float64{1, 2, 3}), &numbers))
+	assert.Equal(t, []int{1, 2, 3}, numbers)
+
+	var byID map[int]string
+	assert.NoError(t, Decode(Collect(map[string]string{"1": "a"}).Map(func(value interface{}, key interface{}, index int) (interface{}, interface{}) {
+		return value, index + 10
+	}), &byID))
+	assert.Equal(t, map[int]string{10: "a"}, byID)
+
+	var people []address
+	assert.NoError(t, Decode(Collect([]interface{}{
+		Collect(map[string]interface{}{"city": "Westview"}),
+		map[string]interface{}{"city": "Eastview"},
+	}), &people))
+	assert.Equal(t, []address{{City: "Westview"}, {City: "Eastview"}}, people)
+}
@@ -0,0 +1,264 @@
+// Package deep implements equality and hashing for values that are not comparable with the == operator,
+// such as slices, maps, and structs holding them.
+package deep
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"math"
+	"reflect"
+)
+
+// The comparison rules are close to reflect.DeepEqual with a few differences:
+//
+//  - nil and empty slices or maps are equal
+//  - pointers, channels and functions are equal only when they point to the same thing
+//  - in numeric mode, numbers are equal when they hold the same value,
+//    whatever their kind, and slices and arrays compare by their elements
+//
+// Hashes follow the same rules, so equal values always have the same hash.
+// Hashes of values holding pointers or channels depend on their addresses.
+
+// Equal reports whether a and b are deeply equal
+func Equal(a, b interface{}) bool {
+	return equal(reflect.ValueOf(a), reflect.ValueOf(b), false)
+}
+
+// EqualNumeric reports whether a and b are deeply equal, comparing numbers by value regardless of their kind
+func EqualNumeric(a, b interface{}) bool {
+	return equal(reflect.ValueOf(a), reflect.ValueOf(b), true)
+}
+
+// Hash gets a hash of the value that is consistent with Equal
+func Hash(v interface{}) uint64 {
+	h := fnv.New64a()
+	write(h, reflect.ValueOf(v), false)
+	return h.Sum64()
+}
+
+// HashNumeric gets a hash of the value that is consistent with EqualNumeric
+func HashNumeric(v interface{}) uint64 {
+	h := fnv.New64a()
+	write(h, reflect.ValueOf(v), true)
+	return h.Sum64()
+}
+
+func equal(a, b reflect.Value, numeric bool) bool {
+	a, b = indirect(a), indirect(b)
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+
+	if numeric {
+		if an, ok := toNumber(a); ok {
+			bn, ok := toNumber(b)
+			return ok && an == bn && !an.isNaN()
+		}
+
+		if isSequence(a) && isSequence(b) {
+			return equalSequence(a, b, numeric)
+		}
+	}
+
+	if a.Type() != b.Type() {
+		return false
+	}
+
+	switch a.Kind() {
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() == b.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return a.Complex() == b.Complex()
+	case reflect.String:
+		return a.String() == b.String()
+	case reflect.Array, reflect.Slice:
+		return equalSequence(a, b, numeric)
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return false
+		}
+
+		iter := a.MapRange()
+		for iter.Next() {
+			value := b.MapIndex(iter.Key())
+			if !value.IsValid() || !equal(iter.Value(), value, numeric) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if !equal(a.Field(i), b.Field(i), numeric) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a.Pointer() == b.Pointer()
+	}
+}
+
+func equalSequence(a, b reflect.Value, numeric bool) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+
+	for i := 0; i < a.Len(); i++ {
+		if !equal(a.Index(i), b.Index(i), numeric) {
+			return false
+		}
+	}
+	return true
+}
+
+// kind tags written in front of every hashed value
+const (
+	tagNil byte = iota
+	tagBool
+	tagInt
+	tagUint
+	tagFloat
+	tagComplex
+	tagString
+	tagSequence
+	tagMap
+	tagStruct
+	tagPointer
+)
+
+func write(h hash.Hash64, v reflect.Value, numeric bool) {
+	v = indirect(v)
+	if !v.IsValid() {
+		h.Write([]byte{tagNil})
+		return
+	}
+
+	if numeric {
+		if n, ok := toNumber(v); ok {
+			h.Write([]byte{n.tag})
+			writeUint(h, n.bits)
+			return
+		}
+	} else {
+		h.Write([]byte(v.Type().String()))
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		h.Write([]byte{tagBool})
+		if v.Bool() {
+			h.Write([]byte{1})
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		h.Write([]byte{tagInt})
+		writeUint(h, uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		h.Write([]byte{tagUint})
+		writeUint(h, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		h.Write([]byte{tagFloat})
+		writeUint(h, math.Float64bits(v.Float()+0))
+	case reflect.Complex64, reflect.Complex128:
+		h.Write([]byte{tagComplex})
+		writeUint(h, math.Float64bits(real(v.Complex())+0))
+		writeUint(h, math.Float64bits(imag(v.Complex())+0))
+	case reflect.String:
+		h.Write([]byte{tagString})
+		writeUint(h, uint64(v.Len()))
+		h.Write([]byte(v.String()))
+	case reflect.Array, reflect.Slice:
+		h.Write([]byte{tagSequence})
+		writeUint(h, uint64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			write(h, v.Index(i), numeric)
+		}
+	case reflect.Map:
+		// entries are hashed separately and summed so the iteration order does not matter
+		var sum uint64
+		iter := v.MapRange()
+		for iter.Next() {
+			entry := fnv.New64a()
+			write(entry, iter.Key(), numeric)
+			write(entry, iter.Value(), numeric)
+			sum += entry.Sum64()
+		}
+		h.Write([]byte{tagMap})
+		writeUint(h, uint64(v.Len()))
+		writeUint(h, sum)
+	case reflect.Struct:
+		h.Write([]byte{tagStruct})
+		for i := 0; i < v.NumField(); i++ {
+			write(h, v.Field(i), numeric)
+		}
+	case reflect.Func:
+		h.Write([]byte{tagPointer})
+		if !v.IsNil() {
+			h.Write([]byte{1})
+		}
+	default:
+		h.Write([]byte{tagPointer})
+		writeUint(h, uint64(v.Pointer()))
+	}
+}
+
+func writeUint(h hash.Hash64, n uint64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], n)
+	h.Write(buf[:])
+}
+
+// indirect unwraps interface values down to their concrete value
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	return v
+}
+
+func isSequence(v reflect.Value) bool {
+	return v.Kind() == reflect.Slice || v.Kind() == reflect.Array
+}
+
+// number is the canonical form of a numeric value,
+// integral values of any kind share the same form
+type number struct {
+	tag  byte
+	bits uint64
+}
+
+func (n number) isNaN() bool {
+	return n.tag == tagFloat && math.IsNaN(math.Float64frombits(n.bits))
+}
+
+func toNumber(v reflect.Value) (number, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return number{tagInt, uint64(v.Int())}, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v.Uint() <= math.MaxInt64 {
+			return number{tagInt, v.Uint()}, true
+		}
+		return number{tagUint, v.Uint()}, true
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		switch {
+		case f != math.Trunc(f):
+			return number{tagFloat, math.Float64bits(f)}, true
+		case f >= math.MinInt64 && f < math.MaxInt64:
+			return number{tagInt, uint64(int64(f))}, true
+		case f >= 0 && f < math.MaxUint64:
+			return number{tagUint, uint64(f)}, true
+		default:
+			return number{tagFloat, math.Float64bits(f)}, true
+		}
+	default:
+		return number{}, false
+	}
+}
@@ -0,0 +1,74 @@
+package deep
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+type point struct {
+	X, Y int
+	tags []string
+}
+
+func TestEqual(t *testing.T) {
+	assert.True(t, Equal(nil, nil))
+	assert.True(t, Equal(1, 1))
+	assert.True(t, Equal("a", "a"))
+	assert.True(t, Equal([]int{1, 2}, []int{1, 2}))
+	assert.True(t, Equal([]int{}, []int(nil)))
+	assert.True(t, Equal(map[string][]int{"a": {1}}, map[string][]int{"a": {1}}))
+	assert.True(t, Equal(point{1, 2, []string{"a"}}, point{1, 2, []string{"a"}}))
+	assert.True(t, Equal([]interface{}{nil, "a"}, []interface{}{nil, "a"}))
+
+	assert.False(t, Equal(nil, 0))
+	assert.False(t, Equal(1, int64(1)))
+	assert.False(t, Equal(1, 1.0))
+	assert.False(t, Equal([]int{1, 2}, []int{2, 1}))
+	assert.False(t, Equal([]int{1}, [1]int{1}))
+	assert.False(t, Equal(map[string]int{"a": 1}, map[string]int{"b": 1}))
+	assert.False(t, Equal(point{1, 2, []string{"a"}}, point{1, 2, []string{"b"}}))
+	assert.False(t, Equal(math.NaN(), math.NaN()))
+
+	a, b := 1, 1
+	assert.True(t, Equal(&a, &a))
+	assert.False(t, Equal(&a, &b))
+}
+
+func TestEqualNumeric(t *testing.T) {
+	assert.True(t, EqualNumeric(1, int64(1)))
+	assert.True(t, EqualNumeric(uint8(1), 1.0))
+	assert.True(t, EqualNumeric(float32(0.5), 0.5))
+	assert.True(t, EqualNumeric([]int{1, 2}, []float64{1, 2}))
+	assert.True(t, EqualNumeric([]interface{}{1, "a"}, [2]interface{}{int8(1), "a"}))
+	assert.True(t, EqualNumeric(map[string]interface{}{"a": 1}, map[string]interface{}{"a": 1.0}))
+	assert.True(t, EqualNumeric(uint64(math.MaxUint64), uint64(math.MaxUint64)))
+
+	assert.False(t, EqualNumeric(1, 1.5))
+	assert.False(t, EqualNumeric(1, "1"))
+	assert.False(t, EqualNumeric(-1, uint64(math.MaxUint64)))
+	assert.False(t, EqualNumeric(math.NaN(), math.NaN()))
+}
+
+func TestHash(t *testing.T) {
+	assert.Equal(t, Hash([]int{1, 2}), Hash([]int{1, 2}))
+	assert.Equal(t, Hash([]int{}), Hash([]int(nil)))
+	assert.Equal(t, Hash(map[string]int{"a": 1, "b": 2, "c": 3}), Hash(map[string]int{"c": 3, "b": 2, "a": 1}))
+	assert.Equal(t, Hash(point{1, 2, []string{"a"}}), Hash(point{1, 2, []string{"a"}}))
+	assert.Equal(t, Hash(0.0), Hash(math.Copysign(0, -1)))
+
+	assert.NotEqual(t, Hash([]int{1, 2}), Hash([]int{2, 1}))
+	assert.NotEqual(t, Hash(1), Hash(int64(1)))
+	assert.NotEqual(t, Hash("ab"), Hash([]string{"a", "b"}))
+	assert.NotEqual(t, Hash(nil), Hash(0))
+}
+
+func TestHashNumeric(t *testing.T) {
+	assert.Equal(t, HashNumeric(1), HashNumeric(int64(1)))
+	assert.Equal(t, HashNumeric(1), HashNumeric(1.0))
+	assert.Equal(t, HashNumeric([]int{1, 2}), HashNumeric([]float64{1, 2}))
+	assert.Equal(t, HashNumeric(map[string]interface{}{"a": 1}), HashNumeric(map[string]interface{}{"a": uint(1)}))
+
+	assert.NotEqual(t, HashNumeric(1), HashNumeric(1.5))
+	assert.NotEqual(t, HashNumeric(1), HashNumeric("1"))
+}
@@ -0,0 +1,52 @@
+package deep
+
+// Set keeps track of distinct values, including values that are not comparable with ==
+type Set struct {
+	numeric bool
+	buckets map[uint64][]interface{}
+}
+
+// NewSet creates a set that compares values with Equal
+func NewSet() *Set {
+	return &Set{buckets: map[uint64][]interface{}{}}
+}
+
+// NewNumericSet creates a set that compares values with EqualNumeric
+func NewNumericSet() *Set {
+	return &Set{numeric: true, buckets: map[uint64][]interface{}{}}
+}
+
+// Has is set contains the value
+func (s *Set) Has(value interface{}) bool {
+	for _, item := range s.buckets[s.hash(value)] {
+		if s.equal(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Add adds the value to the set, it returns false when the value is already in the set
+func (s *Set) Add(value interface{}) bool {
+	if s.Has(value) {
+		return false
+	}
+
+	sum := s.hash(value)
+	s.buckets[sum] = append(s.buckets[sum], value)
+	return true
+}
+
+func (s *Set) hash(value interface{}) uint64 {
+	if s.numeric {
+		return HashNumeric(value)
+	}
+	return Hash(value)
+}
+
+func (s *Set) equal(a, b interface{}) bool {
+	if s.numeric {
+		return EqualNumeric(a, b)
+	}
+	return Equal(a, b)
+}
@@ -0,0 +1,28 @@
+package deep
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+	set := NewSet()
+	assert.True(t, set.Add([]int{1, 2}))
+	assert.True(t, set.Add(1))
+	assert.True(t, set.Add(1.0))
+	assert.False(t, set.Add([]int{1, 2}))
+	assert.False(t, set.Add(1))
+	assert.False(t, set.Has(map[string]int(nil)))
+	assert.True(t, set.Has([]int{1, 2}))
+}
+
+func TestNumericSet(t *testing.T) {
+	set := NewNumericSet()
+	assert.True(t, set.Add(1))
+	assert.False(t, set.Add(1.0))
+	assert.False(t, set.Add(int8(1)))
+	assert.True(t, set.Add(1.5))
+	assert.True(t, set.Add([]int{1}))
+	assert.False(t, set.Add([]float64{1}))
+	assert.True(t, set.Has(uint(1)))
+}
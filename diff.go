@@ -0,0 +1,489 @@
+package collection
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/habibimustafa/collection/arr"
+	"github.com/habibimustafa/collection/deep"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ChangeType describes how an entry differs between two collections
+type ChangeType string
+
+const (
+	// Added means the entry only exists in the new collection
+	Added ChangeType = "added"
+
+	// Removed means the entry only exists in the old collection
+	Removed ChangeType = "removed"
+
+	// Changed means the entry exists in both collections with different values
+	Changed ChangeType = "changed"
+
+	// Moved means the value of a removed entry reappears under a new key,
+	// or, when From equals Path, that the entry moves to the end to follow the key order of the new collection
+	Moved ChangeType = "moved"
+)
+
+// Change represents a single difference between two collections
+type Change struct {
+	// Type is the kind of the change
+	Type ChangeType
+
+	// Path is the keys leading to the entry through nested collections
+	Path []interface{}
+
+	// From is the previous path of a moved entry
+	From []interface{}
+
+	// OldValue is the value in the old collection
+	OldValue interface{}
+
+	// NewValue is the value in the new collection
+	NewValue interface{}
+}
+
+// Changes represents an ordered list of differences between two collections
+type Changes []Change
+
+// Diff compares two collections and lists the changes needed to turn old into new.
+// Nested collections, and nested plain maps on both sides, are compared entry by entry,
+// the entries of plain maps have no order.
+// Removed, changed and moved entries are listed in the order of the old keys,
+// followed by the added entries in the order of the new keys, and then by the entries
+// that move to the end so the keys end up in the order of the new collection.
+func Diff(old Collection, new Collection) Changes {
+	return diff(old, new, nil, true)
+}
+
+// diff lists the changes below the path, the key order is only compared when the entries are ordered
+func diff(old Collection, new Collection, path []interface{}, ordered bool) Changes {
+	oldKeys, newKeys := old.Keys(), new.Keys()
+	oldValues, newValues := old.Values(), new.Values()
+
+	moved := map[int]int{}
+	for i, key := range oldKeys {
+		if newKeys.Has(key) {
+			continue
+		}
+
+		for j, newKey := range newKeys {
+			if _, taken := moved[j]; taken || oldKeys.Has(newKey) {
+				continue
+			}
+
+			if deep.Equal(oldValues[i], newValues[j]) {
+				moved[j] = i
+				break
+			}
+		}
+	}
+
+	var changes Changes
+	for i, key := range oldKeys {
+		j := newKeys.Index(key)
+		if j > -1 {
+			oldNested, oldOk := nestedOf(oldValues[i])
+			newNested, newOk := nestedOf(newValues[j])
+			_, oldCollection := oldValues[i].(Collection)
+			_, newCollection := newValues[j].(Collection)
+			if oldOk && newOk && oldCollection == newCollection {
+				changes = append(changes, diff(oldNested, newNested, childPath(path, key), oldCollection)...)
+				continue
+			}
+
+			if !deep.Equal(oldValues[i], newValues[j]) {
+				changes = append(changes, Change{Type: Changed, Path: childPath(path, key), OldValue: oldValues[i], NewValue: newValues[j]})
+			}
+			continue
+		}
+
+		change := Change{Type: Removed, Path: childPath(path, key), OldValue: oldValues[i]}
+		for j, from := range moved {
+			if from == i {
+				change = Change{Type: Moved, Path: childPath(path, newKeys[j]), From: change.Path, OldValue: oldValues[i], NewValue: newValues[j]}
+			}
+		}
+		changes = append(changes, change)
+	}
+
+	for j, key := range newKeys {
+		if _, ok := moved[j]; ok || oldKeys.Has(key) {
+			continue
+		}
+
+		changes = append(changes, Change{Type: Added, Path: childPath(path, key), NewValue: newValues[j]})
+	}
+
+	if !ordered {
+		return changes
+	}
+	return append(changes, reorder(oldKeys, newKeys, newValues, changes, path)...)
+}
+
+// reorder lists the moves that put the keys in the order of the new keys once the changes are applied.
+// Applying the changes keeps the old keys in place and appends the moved and added keys,
+// so the longest prefix of the new keys already in order stays and the rest moves to the end.
+func reorder(oldKeys arr.Array, newKeys arr.Array, newValues arr.Array, changes Changes, path []interface{}) Changes {
+	var order []interface{}
+	for _, key := range oldKeys {
+		if newKeys.Has(key) {
+			order = append(order, key)
+		}
+	}
+
+	for _, change := range changes {
+		if len(change.Path) == len(path)+1 && (change.Type == Added || change.Type == Moved) {
+			order = append(order, change.Path[len(path)])
+		}
+	}
+
+	kept := 0
+	for _, key := range order {
+		if kept < len(newKeys) && newKeys[kept] == key {
+			kept++
+		}
+	}
+
+	var moves Changes
+	for j := kept; j < len(newKeys); j++ {
+		keyPath := childPath(path, newKeys[j])
+		moves = append(moves, Change{Type: Moved, Path: keyPath, From: keyPath, OldValue: newValues[j], NewValue: newValues[j]})
+	}
+	return moves
+}
+
+// nestedOf gets the value as a collection when it is a collection or a plain map
+func nestedOf(value interface{}) (Collection, bool) {
+	if c, ok := value.(Collection); ok {
+		return c, true
+	}
+
+	if value != nil && reflect.TypeOf(value).Kind() == reflect.Map {
+		return Collect(value), true
+	}
+	return nil, false
+}
+
+// restore puts the patched entries of a nested value back into its own type, plain maps stay plain maps
+func restore(original interface{}, patched Collection) (interface{}, error) {
+	if _, ok := original.(Collection); ok {
+		return patched, nil
+	}
+
+	typ := reflect.TypeOf(original)
+	m := reflect.MakeMapWithSize(typ, patched.Size())
+	values := patched.Values()
+	for i, key := range patched.Keys() {
+		value := reflect.Zero(typ.Elem())
+		if values[i] != nil {
+			value = reflect.ValueOf(values[i])
+		}
+
+		if key == nil || !reflect.TypeOf(key).AssignableTo(typ.Key()) || !value.Type().AssignableTo(typ.Elem()) {
+			return nil, fmt.Errorf("collection: cannot put the %T value at key %v into a %s", values[i], key, typ)
+		}
+		m.SetMapIndex(reflect.ValueOf(key), value)
+	}
+	return m.Interface(), nil
+}
+
+func childPath(path []interface{}, key interface{}) []interface{} {
+	return append(append([]interface{}{}, path...), key)
+}
+
+// Operation represents a single RFC 6902 JSON Patch operation
+type Operation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	From  string      `json:"from,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// MarshalJSON encodes the operation, keeping the value of add, replace and test operations even when it is empty
+func (o Operation) MarshalJSON() ([]byte, error) {
+	type operation Operation
+	if o.Op != "add" && o.Op != "replace" && o.Op != "test" {
+		return json.Marshal(operation(o))
+	}
+
+	return json.Marshal(struct {
+		Op    string      `json:"op"`
+		Path  string      `json:"path"`
+		Value interface{} `json:"value"`
+	}{o.Op, o.Path, o.Value})
+}
+
+// Patch represents an RFC 6902 JSON Patch document
+type Patch []Operation
+
+// ToJSONPatch converts the changes into an RFC 6902 JSON Patch
+func (c Changes) ToJSONPatch() Patch {
+	patch := Patch{}
+	for _, change := range c {
+		switch change.Type {
+		case Added:
+			patch = append(patch, Operation{Op: "add", Path: pointer(change.Path), Value: change.NewValue})
+		case Removed:
+			patch = append(patch, Operation{Op: "remove", Path: pointer(change.Path)})
+		case Changed:
+			patch = append(patch, Operation{Op: "replace", Path: pointer(change.Path), Value: change.NewValue})
+		case Moved:
+			patch = append(patch, Operation{Op: "move", From: pointer(change.From), Path: pointer(change.Path)})
+		}
+	}
+	return patch
+}
+
+// pointer formats a path of keys as a JSON Pointer
+func pointer(path []interface{}) string {
+	var buf strings.Builder
+	for _, key := range path {
+		buf.WriteString("/")
+		buf.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(fmt.Sprint(key)))
+	}
+	return buf.String()
+}
+
+// segments splits a JSON Pointer into its unescaped reference tokens
+func segments(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("collection: invalid JSON pointer %q", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return tokens, nil
+}
+
+// ApplyPatch applies an RFC 6902 JSON Patch to the collection and returns the patched collection.
+// Path segments are matched against the formatted keys, and nested collections and plain maps are patched in place.
+// New keys are appended to the end of their collection, so a move onto its own path moves the entry to the end.
+func ApplyPatch(collection Collection, patch Patch) (Collection, error) {
+	var err error
+	for _, op := range patch {
+		collection, err = applyOperation(collection, op)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return collection, nil
+}
+
+func applyOperation(collection Collection, op Operation) (Collection, error) {
+	path, err := segments(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add":
+		return patchAdd(collection, path, op.Value, op.Path)
+	case "remove":
+		removed, err := patchRemove(collection, path)
+		return removed, pathError(err, op.Path)
+	case "replace":
+		if _, err := patchGet(collection, path); err != nil {
+			return nil, pathError(err, op.Path)
+		}
+		return patchAdd(collection, path, op.Value, op.Path)
+	case "move", "copy":
+		from, err := segments(op.From)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := patchGet(collection, from)
+		if err != nil {
+			return nil, pathError(err, op.From)
+		}
+
+		if op.Op == "move" {
+			if collection, err = patchRemove(collection, from); err != nil {
+				return nil, pathError(err, op.From)
+			}
+		}
+		return patchAdd(collection, path, value, op.Path)
+	case "test":
+		value, err := patchGet(collection, path)
+		if err != nil {
+			return nil, pathError(err, op.Path)
+		}
+
+		if !deep.Equal(value, op.Value) {
+			return nil, fmt.Errorf("collection: test failed for path %q", op.Path)
+		}
+		return collection, nil
+	default:
+		return nil, fmt.Errorf("collection: unknown patch operation %q", op.Op)
+	}
+}
+
+func patchGet(collection Collection, path []string) (interface{}, error) {
+	if len(path) == 0 {
+		return collection, nil
+	}
+
+	key, ok := patchKey(collection, path[0])
+	if !ok {
+		return nil, errPathNotExist
+	}
+
+	value := collection.GetValue(key)
+	if len(path) == 1 {
+		return value, nil
+	}
+
+	nested, ok := nestedOf(value)
+	if !ok {
+		return nil, errPathNotExist
+	}
+	return patchGet(nested, path[1:])
+}
+
+// errPathNotExist is returned by the patch helpers when a path cannot be resolved
+var errPathNotExist = errors.New("path does not exist")
+
+// pathError adds the JSON Pointer to an unresolved path error
+func pathError(err error, pointer string) error {
+	if err == errPathNotExist {
+		return fmt.Errorf("collection: path %q does not exist", pointer)
+	}
+	return err
+}
+
+func patchAdd(collection Collection, path []string, value interface{}, pointer string) (Collection, error) {
+	patched, err := patchSet(collection, path, value)
+	return patched, pathError(err, pointer)
+}
+
+func patchSet(collection Collection, path []string, value interface{}) (Collection, error) {
+	if len(path) == 0 {
+		root, ok := value.(Collection)
+		if !ok {
+			return nil, fmt.Errorf("collection: the root value must be a collection")
+		}
+		return root, nil
+	}
+
+	key, ok := patchKey(collection, path[0])
+	if len(path) == 1 {
+		if !ok {
+			newKey, err := patchNewKey(collection, path[0])
+			if err != nil {
+				return nil, err
+			}
+			return collection.Append(newKey, value), nil
+		}
+		return collection.Set(key, value), nil
+	}
+
+	if !ok {
+		return nil, errPathNotExist
+	}
+
+	original := collection.GetValue(key)
+	nested, ok := nestedOf(original)
+	if !ok {
+		return nil, errPathNotExist
+	}
+
+	patched, err := patchSet(nested, path[1:], value)
+	if err != nil {
+		return nil, err
+	}
+
+	restored, err := restore(original, patched)
+	if err != nil {
+		return nil, err
+	}
+	return collection.Set(key, restored), nil
+}
+
+func patchRemove(collection Collection, path []string) (Collection, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("collection: cannot remove the root collection")
+	}
+
+	key, ok := patchKey(collection, path[0])
+	if !ok {
+		return nil, errPathNotExist
+	}
+
+	if len(path) == 1 {
+		return collection.Unset(key), nil
+	}
+
+	original := collection.GetValue(key)
+	nested, ok := nestedOf(original)
+	if !ok {
+		return nil, errPathNotExist
+	}
+
+	patched, err := patchRemove(nested, path[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	restored, err := restore(original, patched)
+	if err != nil {
+		return nil, err
+	}
+	return collection.Set(key, restored), nil
+}
+
+// patchKey finds the key whose formatted value matches the path segment
+func patchKey(collection Collection, segment string) (interface{}, bool) {
+	for _, key := range collection.Keys() {
+		if fmt.Sprint(key) == segment {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+// patchNewKey converts the path segment into a key of the same type as the existing keys
+func patchNewKey(collection Collection, segment string) (interface{}, error) {
+	if collection.Size() == 0 {
+		return segment, nil
+	}
+
+	keyType := reflect.TypeOf(collection.Keys().First())
+	switch keyType.Kind() {
+	case reflect.String:
+		return reflect.ValueOf(segment).Convert(keyType).Interface(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if segment == "-" {
+			return reflect.ValueOf(nextIntKey(collection)).Convert(keyType).Interface(), nil
+		}
+
+		n, err := strconv.ParseInt(segment, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("collection: %q is not a valid integer key", segment)
+		}
+		return reflect.ValueOf(n).Convert(keyType).Interface(), nil
+	default:
+		return nil, fmt.Errorf("collection: cannot create a key of type %s", keyType)
+	}
+}
+
+// nextIntKey gets the integer key that follows the largest integer key
+func nextIntKey(collection Collection) int64 {
+	var next int64
+	for _, key := range collection.Keys() {
+		if n := reflect.ValueOf(key).Int(); n >= next {
+			next = n + 1
+		}
+	}
+	return next
+}
@@ -0,0 +1,114 @@
+package collection
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	old := Collect(map[string]interface{}{"host": "localhost", "port": 8080, "user": "root", "tags": []string{"a"}}).
+		Set("db", Collect(map[string]interface{}{"name": "app", "pool": 5}))
+	new := Collect(map[string]interface{}{"host": "example.com", "owner": "root", "tags": []string{"a"}, "debug": true}).
+		Set("db", Collect(map[string]interface{}{"name": "app", "pool": 10}))
+
+	changes := Diff(old, new)
+	assert.Equal(t, Changes{
+		{Type: Changed, Path: []interface{}{"host"}, OldValue: "localhost", NewValue: "example.com"},
+		{Type: Removed, Path: []interface{}{"port"}, OldValue: 8080},
+		{Type: Moved, Path: []interface{}{"owner"}, From: []interface{}{"user"}, OldValue: "root", NewValue: "root"},
+		{Type: Changed, Path: []interface{}{"db", "pool"}, OldValue: 5, NewValue: 10},
+		{Type: Added, Path: []interface{}{"debug"}, NewValue: true},
+		{Type: Moved, Path: []interface{}{"host"}, From: []interface{}{"host"}, OldValue: "example.com", NewValue: "example.com"},
+		{Type: Moved, Path: []interface{}{"owner"}, From: []interface{}{"owner"}, OldValue: "root", NewValue: "root"},
+		{Type: Moved, Path: []interface{}{"tags"}, From: []interface{}{"tags"}, OldValue: []string{"a"}, NewValue: []string{"a"}},
+		{Type: Moved, Path: []interface{}{"db"}, From: []interface{}{"db"}, OldValue: new.GetValue("db"), NewValue: new.GetValue("db")},
+	}, changes)
+
+	assert.Empty(t, Diff(Collect(arrString), Collect(arrString)))
+}
+
+func TestDiffKeyOrder(t *testing.T) {
+	old := Collect(nil).Append("a", 1).Append("b", 2).Append("c", 3)
+	new := Collect(nil).Append("c", 3).Append("a", 1).Append("b", 2)
+
+	assert.False(t, old.Equals(new))
+	assert.Equal(t, Changes{
+		{Type: Moved, Path: []interface{}{"a"}, From: []interface{}{"a"}, OldValue: 1, NewValue: 1},
+		{Type: Moved, Path: []interface{}{"b"}, From: []interface{}{"b"}, OldValue: 2, NewValue: 2},
+	}, Diff(old, new))
+
+	patched, err := ApplyPatch(old, Diff(old, new).ToJSONPatch())
+	assert.NoError(t, err)
+	assert.True(t, patched.Equals(new))
+}
+
+func TestDiffPlainMaps(t *testing.T) {
+	old := Collect(map[string]interface{}{"db": map[string]interface{}{"name": "app", "pool": 5, "user": "root"}})
+	new := Collect(map[string]interface{}{"db": map[string]interface{}{"name": "app", "pool": 10, "owner": "root"}})
+
+	changes := Diff(old, new)
+	assert.Equal(t, Changes{
+		{Type: Changed, Path: []interface{}{"db", "pool"}, OldValue: 5, NewValue: 10},
+		{Type: Moved, Path: []interface{}{"db", "owner"}, From: []interface{}{"db", "user"}, OldValue: "root", NewValue: "root"},
+	}, changes)
+
+	patched, err := ApplyPatch(old, changes.ToJSONPatch())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "app", "pool": 10, "owner": "root"}, patched.GetValue("db"))
+	assert.True(t, patched.Equals(new))
+
+	_, err = ApplyPatch(Collect(map[string]interface{}{"db": map[string]int{"pool": 5}}), Patch{{Op: "add", Path: "/db/name", Value: "app"}})
+	assert.EqualError(t, err, "collection: cannot put the string value at key name into a map[string]int")
+}
+
+func TestDiffToJSONPatch(t *testing.T) {
+	old := Collect(map[string]interface{}{"a/b": 1, "c": 2, "d": 3})
+	new := Collect(map[string]interface{}{"a/b": 1, "c": 0, "e": 3})
+
+	patch := Diff(old, new).ToJSONPatch()
+	assert.Equal(t, Patch{
+		{Op: "replace", Path: "/c", Value: 0},
+		{Op: "move", From: "/d", Path: "/e"},
+	}, patch)
+
+	encoded, err := json.Marshal(patch)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"op":"replace","path":"/c","value":0},{"op":"move","from":"/d","path":"/e"}]`, string(encoded))
+	assert.Equal(t, "/a~1b/x~0y", pointer([]interface{}{"a/b", "x~y"}))
+}
+
+func TestApplyPatch(t *testing.T) {
+	old := Collect(map[string]interface{}{"host": "localhost", "port": 8080, "user": "root"}).
+		Set("db", Collect(map[string]interface{}{"name": "app", "pool": 5}))
+	new := Collect(map[string]interface{}{"host": "example.com", "owner": "root", "debug": true}).
+		Set("db", Collect(map[string]interface{}{"name": "app", "pool": 10}))
+
+	patched, err := ApplyPatch(old, Diff(old, new).ToJSONPatch())
+	assert.NoError(t, err)
+	assert.Empty(t, Diff(patched, new))
+	assert.True(t, patched.Equals(new))
+	assert.Equal(t, []interface{}{"debug", "host", "owner", "db"}, patched.Keys().All())
+
+	patched, err = ApplyPatch(Collect(arrString), Patch{
+		{Op: "test", Path: "/0", Value: "Hello"},
+		{Op: "add", Path: "/-", Value: "Now"},
+		{Op: "copy", From: "/0", Path: "/9"},
+		{Op: "remove", Path: "/1"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{0, 2, 3, 4, 5, 9}, patched.Keys().All())
+	assert.Equal(t, []interface{}{"Hello", "Are", "You", "Ready", "Now", "Hello"}, patched.Values().All())
+
+	_, err = ApplyPatch(old, Patch{{Op: "remove", Path: "/db/missing"}})
+	assert.EqualError(t, err, `collection: path "/db/missing" does not exist`)
+
+	_, err = ApplyPatch(old, Patch{{Op: "test", Path: "/host", Value: "example.com"}})
+	assert.EqualError(t, err, `collection: test failed for path "/host"`)
+
+	_, err = ApplyPatch(old, Patch{{Op: "swap", Path: "/host"}})
+	assert.EqualError(t, err, `collection: unknown patch operation "swap"`)
+
+	_, err = ApplyPatch(Collect(arrString), Patch{{Op: "add", Path: "/x", Value: 1}})
+	assert.EqualError(t, err, `collection: "x" is not a valid integer key`)
+}
@@ -0,0 +1,69 @@
+package collection
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Dot flattens nested collections and maps into a single level collection with dot path keys,
+// like "db.host". Empty nested collections and maps are kept as values.
+func (c collect) Dot() Collection {
+	return c.dot(collect{}, "")
+}
+
+func (c collect) dot(flattened collect, path string) collect {
+	for i, key := range c.keys {
+		keyPath := joinPath(path, fmt.Sprint(key))
+		if nested, ok := dotNested(c.values[i]); ok && nested.Size() > 0 {
+			flattened = nested.dot(flattened, keyPath)
+			continue
+		}
+		flattened = flattened.put(keyPath, c.values[i])
+	}
+	return flattened
+}
+
+// dotNested gets a collection or a map as a collection
+func dotNested(value interface{}) (collect, bool) {
+	if c, ok := value.(collect); ok {
+		return c, true
+	}
+
+	if value != nil && reflect.ValueOf(value).Kind() == reflect.Map {
+		return Collect(value).(collect), true
+	}
+	return collect{}, false
+}
+
+// Undot expands the string keys holding dots into nested collections, it reverses Dot.
+// Nested keys keep the order of their first appearance, and nested maps are converted into
+// collections when more keys are added to them. A value is replaced by a nested collection
+// when a later key uses it as a parent.
+func (c collect) Undot() Collection {
+	undotted := c.with(nil, nil)
+	for i, key := range c.keys {
+		path, ok := key.(string)
+		if !ok {
+			undotted = undotted.put(key, c.values[i])
+			continue
+		}
+		undotted = undotted.undot(strings.Split(path, "."), c.values[i])
+	}
+	return undotted
+}
+
+func (c collect) undot(path []string, value interface{}) collect {
+	if len(path) == 1 {
+		return c.put(path[0], value)
+	}
+
+	child := collect{}
+	if index := c.indexOf(path[0]); index > -1 {
+		if existing, ok := dotNested(c.values[index]); ok {
+			// copied so that nested collections of the source are not updated in place
+			child = existing.with(append([]interface{}{}, existing.keys...), append([]interface{}{}, existing.values...))
+		}
+	}
+	return c.put(path[0], child.undot(path[1:], value))
+}
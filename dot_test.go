@@ -0,0 +1,50 @@
+package collection
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCollectionDot(t *testing.T) {
+	c := Collect(map[string]interface{}{
+		"name": "app",
+		"db": Collect(map[string]interface{}{
+			"host":    "localhost",
+			"options": map[string]int{"pool": 5},
+		}),
+		"empty": Collect(nil),
+		"tags":  []string{"a", "b"},
+	})
+
+	dotted := c.Dot()
+	assert.Equal(t, []interface{}{"db.host", "db.options.pool", "empty", "name", "tags"}, dotted.Keys().All())
+	assert.Equal(t, []interface{}{"localhost", 5, Collect(nil), "app", []string{"a", "b"}}, dotted.Values().All())
+
+	indexed := Collect([]interface{}{"a", Collect([]string{"b"})}).Dot()
+	assert.Equal(t, []interface{}{"0", "1.0"}, indexed.Keys().All())
+}
+
+func TestCollectionUndot(t *testing.T) {
+	c := Collect(map[string]interface{}{
+		"db.host":         "localhost",
+		"db.options.pool": 5,
+		"name":            "app",
+	})
+
+	undotted := c.Undot()
+	assert.Equal(t, []interface{}{"db", "name"}, undotted.Keys().All())
+
+	db := undotted.GetValue("db").(Collection)
+	assert.Equal(t, []interface{}{"host", "options"}, db.Keys().All())
+	assert.Equal(t, 5, db.GetValue("options").(Collection).GetValue("pool"))
+
+	assert.True(t, undotted.DeepEquals(undotted.Dot().Undot()))
+
+	source := Collect(map[string]interface{}{"db": Collect(map[string]string{"host": "localhost"})})
+	extended := source.Append("db.port", 5432).Undot()
+	assert.Equal(t, []interface{}{"host", "port"}, extended.GetValue("db").(Collection).Keys().All())
+	assert.Equal(t, 1, source.GetValue("db").(Collection).Size())
+
+	replaced := Collect(map[string]interface{}{"db": "sqlite", "db.host": "localhost"}).Undot()
+	assert.Equal(t, "localhost", replaced.GetValue("db").(Collection).GetValue("host"))
+}
@@ -0,0 +1,88 @@
+package collection
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// FromEnv collects the environment variables starting with the prefix and the separator into
+// nested collections. The rest of the name is lower-cased and split by the separator, so with
+// the "APP" prefix and the "_" separator, APP_DB_HOST becomes the key "host" inside the key "db".
+// All the variables are collected when the prefix is empty. Keys are sorted, and values are strings.
+func FromEnv(prefix string, separator string) Collection {
+	if separator == "" {
+		panic("the separator must not be empty")
+	}
+
+	if prefix != "" {
+		prefix += separator
+	}
+
+	variables := map[string]interface{}{}
+	for _, variable := range os.Environ() {
+		name, value := variable, ""
+		if i := strings.Index(variable, "="); i > -1 {
+			name, value = variable[:i], variable[i+1:]
+		}
+
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		var segments []string
+		for _, segment := range strings.Split(strings.ToLower(name[len(prefix):]), separator) {
+			if segment != "" {
+				segments = append(segments, segment)
+			}
+		}
+
+		if len(segments) > 0 {
+			variables[strings.Join(segments, ".")] = value
+		}
+	}
+
+	return Collect(variables).Undot()
+}
+
+// FromFlagSet collects the flags of the flag set into nested collections, including the flags
+// that are not set. Dots in the flag names nest their values, so the flag "db.host" becomes
+// the key "host" inside the key "db". Values come from flag.Getter when the flag value
+// implements it, otherwise they are the flag value strings. Keys are sorted.
+func FromFlagSet(flags *flag.FlagSet) Collection {
+	values := map[string]interface{}{}
+	flags.VisitAll(func(f *flag.Flag) {
+		if getter, ok := f.Value.(flag.Getter); ok {
+			values[f.Name] = getter.Get()
+			return
+		}
+		values[f.Name] = f.Value.String()
+	})
+
+	return Collect(values).Undot()
+}
+
+// ToEnv formats the collection as environment variables like "APP_DB_HOST=localhost", in the order of Dot.
+// Names are the prefix and the dot path keys joined by underscores, upper-cased, with every character
+// other than letters and digits replaced by an underscore. Values are formatted with fmt.Sprint.
+func (c collect) ToEnv(prefix string) []string {
+	flattened := c.Dot().(collect)
+	variables := make([]string, 0, flattened.Size())
+	for i, key := range flattened.keys {
+		name := fmt.Sprint(key)
+		if prefix != "" {
+			name = prefix + "_" + name
+		}
+
+		name = strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return unicode.ToUpper(r)
+			}
+			return '_'
+		}, name)
+		variables = append(variables, name+"="+fmt.Sprint(flattened.values[i]))
+	}
+	return variables
+}
@@ -0,0 +1,66 @@
+package collection
+
+import (
+	"flag"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("APP_DB_HOST", "localhost")
+	t.Setenv("APP_DB_PORT", "5432")
+	t.Setenv("APP_NAME", "service")
+	t.Setenv("APP_LOG__LEVEL", "debug")
+	t.Setenv("APPLICATION", "ignored")
+	t.Setenv("OTHER_NAME", "ignored")
+
+	c := FromEnv("APP", "_")
+	assert.Equal(t, []interface{}{"db", "log", "name"}, c.Keys().All())
+	assert.Equal(t, "service", c.GetValue("name"))
+
+	db := c.GetValue("db").(Collection)
+	assert.Equal(t, []interface{}{"host", "port"}, db.Keys().All())
+	assert.Equal(t, []interface{}{"localhost", "5432"}, db.Values().All())
+	assert.Equal(t, "debug", c.GetValue("log").(Collection).GetValue("level"))
+
+	t.Setenv("SVC__CACHE__TTL", "60")
+	assert.Equal(t, "60", FromEnv("SVC", "__").GetValue("cache").(Collection).GetValue("ttl"))
+
+	assert.True(t, FromEnv("", "_").Has("app"))
+	assert.PanicsWithValue(t, "the separator must not be empty", func() { FromEnv("APP", "") })
+}
+
+func TestFromFlagSet(t *testing.T) {
+	flags := flag.NewFlagSet("service", flag.ContinueOnError)
+	flags.String("db.host", "localhost", "")
+	flags.Int("db.port", 5432, "")
+	flags.Bool("verbose", false, "")
+	flags.Duration("timeout", time.Second, "")
+	assert.NoError(t, flags.Parse([]string{"-db.port=6432", "-verbose"}))
+
+	c := FromFlagSet(flags)
+	assert.Equal(t, []interface{}{"db", "timeout", "verbose"}, c.Keys().All())
+	assert.Equal(t, true, c.GetValue("verbose"))
+	assert.Equal(t, time.Second, c.GetValue("timeout"))
+
+	db := c.GetValue("db").(Collection)
+	assert.Equal(t, []interface{}{"localhost", 6432}, db.Values().All())
+}
+
+func TestCollectionToEnv(t *testing.T) {
+	c := Collect(map[string]interface{}{
+		"db":        Collect(map[string]interface{}{"host": "localhost", "port": 5432}),
+		"log-level": "debug",
+	})
+
+	assert.Equal(t, []string{"APP_DB_HOST=localhost", "APP_DB_PORT=5432", "APP_LOG_LEVEL=debug"}, c.ToEnv("APP"))
+	assert.Equal(t, []string{"DB_HOST=localhost", "DB_PORT=5432", "LOG_LEVEL=debug"}, c.ToEnv(""))
+
+	for _, variable := range c.ToEnv("RT") {
+		parts := strings.SplitN(variable, "=", 2)
+		t.Setenv(parts[0], parts[1])
+	}
+	assert.Equal(t, "5432", FromEnv("RT", "_").GetValue("db").(Collection).GetValue("port"))
+}
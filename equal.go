@@ -0,0 +1,130 @@
+package collection
+
+import (
+	"encoding/binary"
+	"github.com/habibimustafa/collection/deep"
+	"hash"
+	"hash/fnv"
+)
+
+// EqualOption configures how DeepEquals compares collections
+type EqualOption func(options *equalOptions)
+
+type equalOptions struct {
+	ignoreOrder bool
+	numeric     bool
+}
+
+// IgnoreOrder compares the items regardless of their position
+func IgnoreOrder() EqualOption {
+	return func(options *equalOptions) {
+		options.ignoreOrder = true
+	}
+}
+
+// NumericEquality compares numbers by value regardless of their type, so int(1) equals float64(1)
+func NumericEquality() EqualOption {
+	return func(options *equalOptions) {
+		options.numeric = true
+	}
+}
+
+// Equals is collection has the same keys and values in the same order
+func (c collect) Equals(other Collection) bool {
+	return c.DeepEquals(other)
+}
+
+// EqualsIgnoreOrder is collection has the same keys and values in any order
+func (c collect) EqualsIgnoreOrder(other Collection) bool {
+	return c.DeepEquals(other, IgnoreOrder())
+}
+
+// DeepEquals is collection deeply equal to the other collection,
+// nested collections are compared with the same options
+func (c collect) DeepEquals(other Collection, options ...EqualOption) bool {
+	opts := equalOptions{}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	if other == nil || c.Size() != other.Size() {
+		return false
+	}
+
+	keys, values := other.Keys(), other.Values()
+	for i := 0; i < c.Size(); i++ {
+		j := i
+		if opts.ignoreOrder {
+			j = -1
+			for k, key := range keys {
+				if opts.equal(c.keys[i], key) {
+					j = k
+					break
+				}
+			}
+
+			if j < 0 {
+				return false
+			}
+		} else if !opts.equal(c.keys[i], keys[j]) {
+			return false
+		}
+
+		if !opts.equalValues(c.values[i], values[j]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (o equalOptions) equal(a, b interface{}) bool {
+	if o.numeric {
+		return deep.EqualNumeric(a, b)
+	}
+	return deep.Equal(a, b)
+}
+
+func (o equalOptions) equalValues(a, b interface{}) bool {
+	nested, ok := a.(Collection)
+	if !ok {
+		return o.equal(a, b)
+	}
+
+	other, ok := b.(Collection)
+	if !ok {
+		return false
+	}
+
+	var options []EqualOption
+	if o.ignoreOrder {
+		options = append(options, IgnoreOrder())
+	}
+	if o.numeric {
+		options = append(options, NumericEquality())
+	}
+	return nested.DeepEquals(other, options...)
+}
+
+// Hash gets a stable hash of the keys and values, collections that are Equals have the same hash
+func (c collect) Hash() uint64 {
+	h := fnv.New64a()
+	for i := 0; i < c.Size(); i++ {
+		writeHash(h, deep.Hash(c.keys[i]))
+		writeHash(h, hashValue(c.values[i]))
+	}
+	return h.Sum64()
+}
+
+func hashValue(value interface{}) uint64 {
+	if nested, ok := value.(Collection); ok {
+		return nested.Hash()
+	}
+	return deep.Hash(value)
+}
+
+func writeHash(h hash.Hash64, sum uint64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], sum)
+	h.Write(buf[:])
+}
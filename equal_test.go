@@ -0,0 +1,59 @@
+package collection
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCollectionEquals(t *testing.T) {
+	assert.True(t, Collect(arrString).Equals(Collect(arrString)))
+	assert.True(t, Collect(arrMap).Equals(Collect(arrMap)))
+	assert.True(t, Collect(nil).Equals(Collect([]int{})))
+	assert.True(t, Collect([][]int{{1}, {2}}).Equals(Collect([][]int{{1}, {2}})))
+
+	assert.False(t, Collect(arrString).Equals(Collect(arrMap)))
+	assert.False(t, Collect(arrString).Equals(Collect(arrString).Set(0, "Hi")))
+	assert.False(t, Collect(arrMap).Equals(Collect(arrMap).Unset("Age").Append("Age", 28)))
+	assert.False(t, Collect([]int{1}).Equals(Collect([]int64{1})))
+	assert.False(t, Collect(arrString).Equals(nil))
+}
+
+func TestCollectionEqualsIgnoreOrder(t *testing.T) {
+	reordered := Collect(arrMap).Unset("Age").Append("Age", 28)
+	assert.True(t, Collect(arrMap).EqualsIgnoreOrder(reordered))
+	assert.False(t, Collect(arrMap).EqualsIgnoreOrder(reordered.Set("Age", 29)))
+	assert.False(t, Collect(arrMap).EqualsIgnoreOrder(reordered.Unset("Age")))
+}
+
+func TestCollectionDeepEquals(t *testing.T) {
+	ints := Collect(map[string]interface{}{"a": 1, "b": []int{2}}).Append("c", Collect([]int{3}))
+	floats := Collect(map[string]interface{}{"a": 1.0, "b": []float64{2}}).Append("c", Collect([]float64{3}))
+
+	assert.False(t, ints.DeepEquals(floats))
+	assert.True(t, ints.DeepEquals(floats, NumericEquality()))
+	assert.False(t, ints.DeepEquals(floats.Set("a", 1.5), NumericEquality()))
+
+	nested := Collect(map[string]interface{}{"a": 1}).Append("b", Collect(map[string]int{"x": 1, "y": 2}))
+	reordered := Collect(nil).Append("b", Collect(map[string]int{"x": 1, "y": 2}).Unset("x").Append("x", 1)).Append("a", 1)
+	assert.False(t, nested.DeepEquals(reordered))
+	assert.True(t, nested.DeepEquals(reordered, IgnoreOrder()))
+}
+
+func TestCollectionHash(t *testing.T) {
+	assert.Equal(t, Collect(arrString).Hash(), Collect(arrString).Hash())
+	assert.Equal(t, Collect(arrMap).Hash(), Collect(map[string]interface{}{"Age": 28, "Last Name": "Doe", "First Name": "John"}).Hash())
+	assert.Equal(t, Collect([][]int{{1}, {2}}).Hash(), Collect([][]int{{1}, {2}}).Hash())
+	assert.Equal(t, Collect(nil).Hash(), Collect([]int{}).Hash())
+
+	assert.NotEqual(t, Collect(arrString).Hash(), Collect(arrString).Set(0, "Hi").Hash())
+	assert.NotEqual(t, Collect(arrMap).Hash(), Collect(arrMap).Unset("Age").Append("Age", 28).Hash())
+	assert.NotEqual(t, Collect([]int{1}).Hash(), Collect([]int64{1}).Hash())
+}
+
+func TestCollectionContainsNotComparable(t *testing.T) {
+	c := Collect(map[string]interface{}{"tags": []string{"a", "b"}})
+	assert.NotPanics(t, func() { c.Contains("tags", []string{"a", "b"}) })
+	assert.True(t, c.Contains("tags", []string{"a", "b"}))
+	assert.False(t, c.Contains("tags", []string{"a"}))
+	assert.False(t, c.Contains("name", nil))
+}
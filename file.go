@@ -0,0 +1,68 @@
+package collection
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Format decodes the content of a file into a collection
+type Format func(data []byte) (Collection, error)
+
+// ErrFormatNotRegistered is returned when reading a file whose extension has no registered format
+var ErrFormatNotRegistered = errors.New("collection: format is not registered")
+
+var formats = struct {
+	sync.RWMutex
+	registry map[string]Format
+}{registry: map[string]Format{
+	".json": FromJSON,
+	".yaml": FromYAML,
+	".yml":  FromYAML,
+}}
+
+// RegisterFormat adds a format for the file extension, registering the same extension again replaces the format.
+// Extensions are case-insensitive, with or without the leading dot. JSON and YAML are registered by default,
+// importing the toml subpackage registers TOML. It is safe to register formats from several goroutines.
+func RegisterFormat(extension string, format Format) {
+	if strings.TrimPrefix(extension, ".") == "" {
+		panic("the format extension must not be empty")
+	}
+
+	if format == nil {
+		panic("the format must not be nil")
+	}
+
+	formats.Lock()
+	defer formats.Unlock()
+	formats.registry[normalizeExtension(extension)] = format
+}
+
+func lookupFormat(extension string) (Format, bool) {
+	formats.RLock()
+	defer formats.RUnlock()
+	format, ok := formats.registry[normalizeExtension(extension)]
+	return format, ok
+}
+
+func normalizeExtension(extension string) string {
+	return "." + strings.ToLower(strings.TrimPrefix(extension, "."))
+}
+
+// FromFile reads the file and decodes it with the format registered for its extension,
+// it returns an error wrapping ErrFormatNotRegistered when there is no such format
+func FromFile(path string) (Collection, error) {
+	format, ok := lookupFormat(filepath.Ext(path))
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrFormatNotRegistered, filepath.Ext(path))
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return format(data)
+}
@@ -0,0 +1,105 @@
+package collection
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestFromJSON(t *testing.T) {
+	c, err := FromJSON([]byte(`{"b": 1, "a": {"y": [1, "x", {"k": null}], "x": true}, "b": 2}`))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []interface{}{"b", "a"}, c.Keys().All())
+	assert.Equal(t, 2.0, c.GetValue("b"))
+
+	nested := c.GetValue("a").(Collection)
+	assert.Equal(t, []interface{}{"y", "x"}, nested.Keys().All())
+	assert.Equal(t, Collect(map[string]interface{}{"k": nil}), nested.GetValue("y").([]interface{})[2])
+
+	list, err := FromJSON([]byte(`[3, 4]`))
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{0, 1}, list.Keys().All())
+
+	_, err = FromJSON([]byte(`"text"`))
+	assert.EqualError(t, err, "collection: cannot decode JSON string into a collection")
+
+	_, err = FromJSON([]byte(`{} {}`))
+	assert.EqualError(t, err, "collection: invalid JSON: unexpected data after the top-level value")
+
+	_, err = FromJSON([]byte(`{"a": `))
+	assert.Error(t, err)
+}
+
+func TestFromJSONLarge(t *testing.T) {
+	const size = 50000
+	var b strings.Builder
+	b.WriteString("{")
+	for i := 0; i < size; i++ {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString(`"k` + strconv.Itoa(i) + `":` + strconv.Itoa(i))
+	}
+	b.WriteString(`,"k0":-1}`)
+
+	c, err := FromJSON([]byte(b.String()))
+	assert.NoError(t, err)
+	assert.Equal(t, size, c.Size())
+	assert.Equal(t, -1.0, c.GetValue("k0"))
+	assert.Equal(t, "k49999", c.Keys().Last())
+}
+
+func TestCollectionJSONRoundTrip(t *testing.T) {
+	data := `{"z":1,"a":{"c":[1,"x"],"b":null}}`
+	c, err := FromJSON([]byte(data))
+	assert.NoError(t, err)
+
+	encoded, err := c.MarshalJSON()
+	assert.NoError(t, err)
+	assert.Equal(t, data, string(encoded))
+}
+
+func TestFromFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, content string) string {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	c, err := FromFile(write("config.yml", "b: 1\na: 2\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"b", "a"}, c.Keys().All())
+
+	c, err = FromFile(write("config.JSON", `{"b": 1, "a": 2}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"b", "a"}, c.Keys().All())
+
+	_, err = FromFile(write("config.ini", "a=1"))
+	assert.True(t, errors.Is(err, ErrFormatNotRegistered))
+	assert.EqualError(t, err, `collection: format is not registered: ".ini"`)
+
+	t.Cleanup(func() {
+		formats.Lock()
+		defer formats.Unlock()
+		delete(formats.registry, ".ini")
+	})
+	RegisterFormat("ini", func(data []byte) (Collection, error) {
+		parts := strings.SplitN(strings.TrimSpace(string(data)), "=", 2)
+		return Collect(map[string]string{parts[0]: parts[1]}), nil
+	})
+	c, err = FromFile(filepath.Join(dir, "config.ini"))
+	assert.NoError(t, err)
+	assert.Equal(t, "1", c.GetValue("a"))
+
+	_, err = FromFile(filepath.Join(dir, "missing.json"))
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+
+	assert.PanicsWithValue(t, "the format extension must not be empty", func() { RegisterFormat(".", FromJSON) })
+	assert.PanicsWithValue(t, "the format must not be nil", func() { RegisterFormat(".txt", nil) })
+}
@@ -2,10 +2,15 @@ module github.com/habibimustafa/collection
 
 go 1.17
 
-require github.com/stretchr/testify v1.7.0
+require (
+	github.com/BurntSushi/toml v1.3.2
+	github.com/stretchr/testify v1.7.0
+	golang.org/x/term v0.10.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
+	golang.org/x/sys v0.10.0 // indirect
 )
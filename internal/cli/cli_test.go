@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+const users = `name,age,city
+Ann,30,Oslo
+Bob,15,Rome
+Cid,22.5,Oslo
+`
+
+func run(t *testing.T, input string, format string, output string, args ...string) string {
+	c, err := Read(strings.NewReader(input), format)
+	assert.NoError(t, err)
+
+	steps, err := Parse(args)
+	assert.NoError(t, err)
+
+	result, err := Run(c, steps)
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.NoError(t, Write(&buf, result, output, false))
+	return buf.String()
+}
+
+func TestParse(t *testing.T) {
+	steps, err := Parse([]string{"where", "age", ">", "18", "sum"})
+	assert.NoError(t, err)
+	assert.Len(t, steps, 2)
+	assert.Equal(t, "where", steps[0].Operation.Name)
+	assert.Equal(t, []string{"age", ">", "18"}, steps[0].Args)
+	assert.Equal(t, "sum", steps[1].Operation.Name)
+
+	_, err = Parse([]string{"shuffle"})
+	assert.EqualError(t, err, `unknown operation "shuffle"`)
+
+	_, err = Parse([]string{"where", "age", ">"})
+	assert.EqualError(t, err, `operation "where" needs 3 arguments: path, operator, value`)
+}
+
+func TestRun(t *testing.T) {
+	assert.Equal(t, `["Cid","Ann"]`+"\n", run(t, users, "csv", "json", "where", "age", ">=", "18", "sort-by", "age", "pluck", "name"))
+	assert.Equal(t, `["Ann","Cid","Bob"]`+"\n", run(t, users, "csv", "json", "sort-by", "-age", "pluck", "name"))
+	assert.Equal(t, `["Bob"]`+"\n", run(t, users, "csv", "json", "where", "city", "!=", "Oslo", "pluck", "name"))
+	assert.Equal(t, `{"Oslo":[{"name":"Ann","age":30,"city":"Oslo"},{"name":"Cid","age":22.5,"city":"Oslo"}],"Rome":[{"name":"Bob","age":15,"city":"Rome"}]}`+"\n", run(t, users, "csv", "json", "group-by", "city"))
+	assert.Equal(t, `{"Oslo":"Ann","Rome":"Bob"}`+"\n", run(t, users, "csv", "json", "group-by", "city", "pluck", "0.name"))
+	assert.Equal(t, `[1,3]`+"\n", run(t, `[{"id":1},{},{"id":3}]`, "json", "json", "pluck", "id"))
+	assert.Equal(t, "67.5\n", run(t, users, "csv", "json", "pluck", "age", "sum"))
+	assert.Equal(t, `[[{"name":"Ann"},{"name":"Bob"}],[{"name":"Cid"}]]`+"\n", run(t, `[{"name":"Ann"},{"name":"Bob"},{"name":"Cid"}]`, "json", "json", "chunk", "2"))
+	assert.Equal(t, `[{"name":"Ann"},{"name":"Bob"}]`+"\n"+`[{"name":"Cid"}]`+"\n", run(t, `[{"name":"Ann"},{"name":"Bob"},{"name":"Cid"}]`, "json", "ndjson", "chunk", "2"))
+	assert.Equal(t, `[{"name":"Ann","age":30,"city":"Oslo"},{"name":"Cid","age":22.5,"city":"Oslo"}]`+"\n"+`[{"name":"Bob","age":15,"city":"Rome"}]`+"\n", run(t, users, "csv", "ndjson", "group-by", "city"))
+}
+
+func TestRunFilter(t *testing.T) {
+	input := `[{"id":1,"tags":["a","b"]},{"id":2,"tags":[]},{"id":3,"active":true,"tags":["b"]}]`
+
+	assert.Equal(t, "[1,3]\n", run(t, input, "json", "json", "filter", "tags", "pluck", "id"))
+	assert.Equal(t, "[3]\n", run(t, input, "json", "json", "filter", "active", "pluck", "id"))
+	assert.Equal(t, "[1]\n", run(t, input, "json", "json", "where", "tags", "contains", "a", "pluck", "id"))
+}
+
+func TestRunOnlyExcept(t *testing.T) {
+	input := `{"a":1,"b":2,"c":3}`
+
+	assert.Equal(t, `{"a":1,"c":3}`+"\n", run(t, input, "json", "json", "only", "a, c"))
+	assert.Equal(t, `{"b":2}`+"\n", run(t, input, "json", "json", "except", "a,c"))
+	assert.Equal(t, `{"1":"y"}`+"\n", run(t, `["x","y"]`, "json", "json", "only", "1"))
+}
+
+func TestRunErrors(t *testing.T) {
+	c, err := Read(strings.NewReader(users), "csv")
+	assert.NoError(t, err)
+
+	steps, _ := Parse([]string{"pluck", "age", "sum", "pluck", "age"})
+	_, err = Run(c, steps)
+	assert.EqualError(t, err, `operation "pluck" cannot follow "sum", it needs a collection`)
+
+	steps, _ = Parse([]string{"sum"})
+	_, err = Run(c, steps)
+	assert.EqualError(t, err, "sum: the values must be numbers to be summed")
+
+	steps, _ = Parse([]string{"where", "age", "~", "1"})
+	_, err = Run(c, steps)
+	assert.EqualError(t, err, `where: unknown operator "~"`)
+
+	steps, _ = Parse([]string{"chunk", "two"})
+	_, err = Run(c, steps)
+	assert.EqualError(t, err, `chunk: the chunk size "two" is not a number`)
+
+	steps, _ = Parse([]string{"chunk", "0"})
+	_, err = Run(c, steps)
+	assert.EqualError(t, err, "chunk: the chunk size must be greater than zero")
+}
+
+func TestReadWrite(t *testing.T) {
+	ndjson := "{\"name\":\"Ann\",\"age\":30}\n\n{\"name\":\"Bob\",\"age\":15}\n"
+	assert.Equal(t, "{\"name\":\"Ann\",\"age\":30}\n{\"name\":\"Bob\",\"age\":15}\n", run(t, ndjson, "ndjson", "ndjson"))
+	assert.Equal(t, "name,age\nAnn,30\nBob,15\n", run(t, ndjson, "ndjson", "csv"))
+	assert.Equal(t, "- name: Ann\n  age: 30\n- name: Bob\n  age: 15\n", run(t, ndjson, "ndjson", "yaml"))
+	assert.Equal(t, "a:\n    - 1\n    - 2\n", run(t, "a: [1, 2]\n", "yaml", "yaml"))
+	assert.Equal(t, "key,value\na,1\nb,\"{\"\"c\"\":[1,2]}\"\n", run(t, `{"a":1,"b":{"c":[1,2]}}`, "json", "csv"))
+	assert.Equal(t, "name,age,city\nAnn,30,\nBob,,Rome\n", run(t, `[{"name":"Ann","age":30},{"name":"Bob","city":"Rome"}]`, "json", "csv"))
+	assert.Equal(t, "[]\n", run(t, users, "csv", "json", "where", "age", ">", "100"))
+
+	_, err := Read(strings.NewReader("{}\n[\n"), "ndjson")
+	assert.EqualError(t, err, "line 2: unexpected end of JSON input")
+
+	_, err = Read(strings.NewReader(""), "xml")
+	assert.EqualError(t, err, `unknown format "xml"`)
+}
+
+func TestReadCSVCells(t *testing.T) {
+	input := "name,zip,score,big,ratio\nNan,00123,-7,12345678901234567890,1.5e3\ninf,0,+5,0x1F,.5\n"
+	assert.Equal(t,
+		`[{"name":"Nan","zip":"00123","score":-7,"big":"12345678901234567890","ratio":1500},`+
+			`{"name":"inf","zip":0,"score":"+5","big":"0x1F","ratio":".5"}]`+"\n",
+		run(t, input, "csv", "json"))
+}
+
+func TestFormatOf(t *testing.T) {
+	assert.Equal(t, "ndjson", FormatOf("logs.jsonl"))
+	assert.Equal(t, "csv", FormatOf("users.CSV"))
+	assert.Equal(t, "yaml", FormatOf("config.yml"))
+	assert.Equal(t, "json", FormatOf("data"))
+}
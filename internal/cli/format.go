@@ -0,0 +1,281 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/habibimustafa/collection"
+	"gopkg.in/yaml.v3"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Formats lists the names of the formats that can be read and written
+var Formats = []string{"json", "ndjson", "csv", "yaml"}
+
+// FormatOf gets the format of a file from its extension, json when the extension is not known
+func FormatOf(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ndjson", ".jsonl":
+		return "ndjson"
+	case ".csv":
+		return "csv"
+	case ".yaml", ".yml":
+		return "yaml"
+	default:
+		return "json"
+	}
+}
+
+// Read decodes a collection in the format.
+// NDJSON lines and CSV records become the items of a collection keyed by their positions,
+// and CSV cells become an int, a float64, or a string keyed by the header row.
+func Read(r io.Reader, format string) (collection.Collection, error) {
+	switch format {
+	case "json":
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		return collection.FromJSON(data)
+	case "yaml", "yml":
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		return collection.FromYAML(data)
+	case "ndjson":
+		return readNDJSON(r)
+	case "csv":
+		return readCSV(r)
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+func readNDJSON(r io.Reader) (collection.Collection, error) {
+	var items []interface{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		item, err := readJSONValue(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		items = append(items, item)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return collection.Collect(items), nil
+}
+
+// readJSONValue decodes objects into collections and everything else like encoding/json
+func readJSONValue(data []byte) (interface{}, error) {
+	if data[0] == '{' {
+		return collection.FromJSON(data)
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func readCSV(r io.Reader) (collection.Collection, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var items []interface{}
+	if len(records) > 0 {
+		header := records[0]
+		for _, record := range records[1:] {
+			row := collection.Collect(nil)
+			for i, cell := range record {
+				row = row.Set(header[i], cell2value(cell))
+			}
+			items = append(items, row)
+		}
+	}
+	return collection.Collect(items), nil
+}
+
+// decimal matches the numbers written like JSON numbers, so words such as "nan" or "inf"
+// and numbers with leading zeros such as zip codes stay strings
+var decimal = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// cell2value reads a plain decimal number as an int, or as a float64 when it has a fraction or an exponent.
+// Other cells and numbers out of range stay strings.
+func cell2value(cell string) interface{} {
+	if !decimal.MatchString(cell) {
+		return cell
+	}
+
+	if n, err := strconv.Atoi(cell); err == nil {
+		return n
+	}
+
+	if strings.ContainsAny(cell, ".eE") {
+		if f, err := strconv.ParseFloat(cell, 64); err == nil {
+			return f
+		}
+	}
+	return cell
+}
+
+// Write encodes the result of Run in the format.
+// Collections keyed by the positions of their items are written as JSON arrays or YAML sequences, NDJSON lines included,
+// and results that are not collections are always written as JSON.
+func Write(w io.Writer, result interface{}, format string, pretty bool) error {
+	c, ok := result.(collection.Collection)
+	if !ok {
+		return writeJSON(w, result, pretty)
+	}
+
+	switch format {
+	case "json":
+		return writeJSON(w, listValue(c), pretty)
+	case "ndjson":
+		for _, value := range c.Values() {
+			if err := writeJSON(w, listValue(value), false); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "yaml", "yml":
+		data, err := yaml.Marshal(listValue(c))
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	case "csv":
+		return writeCSV(w, c)
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
+
+func writeJSON(w io.Writer, value interface{}, pretty bool) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	if pretty {
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, data, "", "  "); err != nil {
+			return err
+		}
+		data = buf.Bytes()
+	}
+
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// listValue turns the collections keyed by the positions of their items into slices, nested ones included
+func listValue(value interface{}) interface{} {
+	c, ok := value.(collection.Collection)
+	if !ok {
+		return value
+	}
+
+	converted := c.MapValues(func(value interface{}, key interface{}, index int) interface{} {
+		return listValue(value)
+	})
+	if !isList(converted) {
+		return converted
+	}
+	return append([]interface{}{}, converted.Values()...)
+}
+
+// writeCSV writes a row for every item when all items are tables, with the keys of the items as header,
+// otherwise it writes a key and value row for every item
+func writeCSV(w io.Writer, c collection.Collection) error {
+	out := csv.NewWriter(w)
+	rows, header, ok := table(c)
+	if ok {
+		if err := out.Write(header); err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			record := make([]string, len(header))
+			for i, column := range header {
+				record[i] = cell(row[column])
+			}
+			if err := out.Write(record); err != nil {
+				return err
+			}
+		}
+	} else {
+		if err := out.Write([]string{"key", "value"}); err != nil {
+			return err
+		}
+
+		values := c.Values()
+		for i, key := range c.Keys() {
+			if err := out.Write([]string{fmt.Sprint(key), cell(values[i])}); err != nil {
+				return err
+			}
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+// table gets the items keyed by their formatted keys, and the keys of all items in order of first appearance
+func table(c collection.Collection) ([]map[string]interface{}, []string, bool) {
+	var rows []map[string]interface{}
+	var header []string
+	seen := map[string]bool{}
+	for _, value := range c.Values() {
+		row, ok := value.(collection.Collection)
+		if !ok {
+			return nil, nil, false
+		}
+
+		cells := map[string]interface{}{}
+		values := row.Values()
+		for i, key := range row.Keys() {
+			column := fmt.Sprint(key)
+			if !seen[column] {
+				seen[column] = true
+				header = append(header, column)
+			}
+			cells[column] = values[i]
+		}
+		rows = append(rows, cells)
+	}
+	return rows, header, len(rows) > 0
+}
+
+// cell formats a value for CSV, nested values are written as JSON
+func cell(value interface{}) string {
+	switch value.(type) {
+	case nil, string, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return format(value)
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
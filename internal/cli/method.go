@@ -0,0 +1,276 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"github.com/habibimustafa/collection"
+	"reflect"
+	gosort "sort"
+	"strconv"
+	"strings"
+)
+
+// method is a method of Collection whose arguments can be read from the words of a line
+type method struct {
+	name string
+	typ  reflect.Type
+	args []argument
+}
+
+// argument reads a value of a parameter type from the words of a line, it returns the words left
+type argument struct {
+	usage string
+	read  func(words []token) (reflect.Value, []token, error)
+}
+
+var (
+	collectionType = reflect.TypeOf((*collection.Collection)(nil)).Elem()
+	errorType      = reflect.TypeOf((*error)(nil)).Elem()
+	interfaceType  = reflect.TypeOf((*interface{})(nil)).Elem()
+	predicateType  = reflect.TypeOf(func(value interface{}, key interface{}, index int) bool { return false })
+	selectorType   = reflect.TypeOf(func(value interface{}, key interface{}, index int) interface{} { return nil })
+	mapperType     = reflect.TypeOf(func(value interface{}, key interface{}, index int) (interface{}, interface{}) { return nil, nil })
+)
+
+// hidden are the methods whose results cannot be shown
+var hidden = map[string]bool{"Match": true, "MarshalJSON": true}
+
+// methods finds the methods of Collection whose parameters can all be read from a line
+func methods() map[string]method {
+	found := map[string]method{}
+	for i := 0; i < collectionType.NumMethod(); i++ {
+		m := collectionType.Method(i)
+		if hidden[m.Name] {
+			continue
+		}
+
+		if args, ok := arguments(m.Type); ok {
+			found[m.Name] = method{name: m.Name, typ: m.Type, args: args}
+		}
+	}
+	return found
+}
+
+func methodNames(methods map[string]method) []string {
+	var names []string
+	for name := range methods {
+		names = append(names, name)
+	}
+	gosort.Strings(names)
+	return names
+}
+
+func arguments(typ reflect.Type) ([]argument, bool) {
+	var args []argument
+	for i := 0; i < typ.NumIn(); i++ {
+		in := typ.In(i)
+		if typ.IsVariadic() && i == typ.NumIn()-1 {
+			arg, ok := argumentOf(in.Elem())
+			if !ok {
+				return nil, false
+			}
+			args = append(args, variadic(arg))
+			continue
+		}
+
+		arg, ok := argumentOf(in)
+		if !ok {
+			return nil, false
+		}
+		args = append(args, arg)
+	}
+	return args, true
+}
+
+// argumentOf gets the argument reading the type:
+// numbers, strings and booleans are read from a word, values from a word as an int, a float64, a bool, or a string,
+// slices from a comma separated word, and callbacks from a path with an optional operator and value
+func argumentOf(typ reflect.Type) (argument, bool) {
+	switch typ {
+	case interfaceType:
+		return word("VALUE", func(w token) (interface{}, error) { return literal(w), nil }), true
+	case predicateType:
+		return argument{usage: "PATH [OP VALUE]", read: readPredicate}, true
+	case selectorType:
+		return word("PATH", func(w token) (interface{}, error) {
+			return func(value interface{}, key interface{}, index int) interface{} {
+				return at(value, w.text)
+			}, nil
+		}), true
+	case mapperType:
+		return word("PATH", func(w token) (interface{}, error) {
+			return func(value interface{}, key interface{}, index int) (interface{}, interface{}) {
+				return at(value, w.text), key
+			}, nil
+		}), true
+	}
+
+	switch typ.Kind() {
+	case reflect.Int:
+		return word("INT", func(w token) (interface{}, error) { return strconv.Atoi(w.text) }), true
+	case reflect.Float64:
+		return word("NUMBER", func(w token) (interface{}, error) { return strconv.ParseFloat(w.text, 64) }), true
+	case reflect.Bool:
+		return word("BOOL", func(w token) (interface{}, error) { return strconv.ParseBool(w.text) }), true
+	case reflect.String:
+		return word("STRING", func(w token) (interface{}, error) { return w.text, nil }), true
+	case reflect.Slice:
+		elem, ok := argumentOf(typ.Elem())
+		if !ok || typ.Elem().Kind() == reflect.Slice || typ.Elem().Kind() == reflect.Func {
+			return argument{}, false
+		}
+		return list(typ, elem), true
+	}
+	return argument{}, false
+}
+
+// word reads an argument from a single word
+func word(usage string, parse func(w token) (interface{}, error)) argument {
+	return argument{usage: usage, read: func(words []token) (reflect.Value, []token, error) {
+		value, err := parse(words[0])
+		if err != nil {
+			return reflect.Value{}, nil, fmt.Errorf("%q is not a valid %s", words[0].text, strings.ToLower(usage))
+		}
+
+		if value == nil {
+			return reflect.Zero(interfaceType), words[1:], nil
+		}
+		return reflect.ValueOf(value), words[1:], nil
+	}}
+}
+
+// list reads a slice from a comma separated word
+func list(typ reflect.Type, elem argument) argument {
+	return argument{usage: elem.usage + ",...", read: func(words []token) (reflect.Value, []token, error) {
+		slice := reflect.MakeSlice(typ, 0, 0)
+		for _, part := range strings.Split(words[0].text, ",") {
+			value, _, err := elem.read([]token{{strings.TrimSpace(part), words[0].quoted}})
+			if err != nil {
+				return reflect.Value{}, nil, err
+			}
+			slice = reflect.Append(slice, value.Convert(typ.Elem()))
+		}
+		return slice, words[1:], nil
+	}}
+}
+
+// variadic reads the remaining words, the value is a slice of them
+func variadic(elem argument) argument {
+	return argument{usage: "[" + elem.usage + "...]", read: func(words []token) (reflect.Value, []token, error) {
+		var values []reflect.Value
+		for len(words) > 0 {
+			value, rest, err := elem.read(words)
+			if err != nil {
+				return reflect.Value{}, nil, err
+			}
+			values, words = append(values, value), rest
+		}
+		return reflect.ValueOf(values), nil, nil
+	}}
+}
+
+func readPredicate(words []token) (reflect.Value, []token, error) {
+	path := words[0].text
+	if len(words) >= 3 && !words[1].quoted {
+		if compare, err := comparison(words[1].text); err == nil {
+			arg := words[2].text
+			return reflect.ValueOf(func(value interface{}, key interface{}, index int) bool {
+				found, ok := lookup(value, path)
+				return ok && compare(found, arg)
+			}), words[3:], nil
+		}
+	}
+
+	return reflect.ValueOf(func(value interface{}, key interface{}, index int) bool {
+		found, ok := lookup(value, path)
+		return ok && truthy(found)
+	}), words[1:], nil
+}
+
+// lookup finds the value at the dot path, "." is the value itself
+func lookup(value interface{}, path string) (interface{}, bool) {
+	if path == "." {
+		return value, true
+	}
+	return collection.Lookup(value, path)
+}
+
+func at(value interface{}, path string) interface{} {
+	found, _ := lookup(value, path)
+	return found
+}
+
+// literal reads an unquoted word as an int, a float64, a bool, or nil when possible
+func literal(w token) interface{} {
+	if w.quoted {
+		return w.text
+	}
+
+	switch w.text {
+	case "true":
+		return true
+	case "false":
+		return false
+	case "null", "nil":
+		return nil
+	}
+	return cell2value(w.text)
+}
+
+func (m method) usage() string {
+	words := []string{m.name}
+	for _, arg := range m.args {
+		words = append(words, arg.usage)
+	}
+	return strings.Join(words, " ")
+}
+
+// call calls the method with the arguments read from the words, a returned error or panic is the error
+func (m method) call(c collection.Collection, words []token) (results []interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			results, err = nil, fmt.Errorf("%s: %v", m.name, r)
+		}
+	}()
+
+	var in []reflect.Value
+	for i, arg := range m.args {
+		variadic := m.typ.IsVariadic() && i == len(m.args)-1
+		if len(words) == 0 && !variadic {
+			return nil, errors.New("usage: " + m.usage())
+		}
+
+		if len(words) == 0 {
+			break
+		}
+
+		value, rest, err := arg.read(words)
+		if err != nil {
+			return nil, err
+		}
+
+		if variadic {
+			for _, v := range value.Interface().([]reflect.Value) {
+				in = append(in, v.Convert(m.typ.In(i).Elem()))
+			}
+		} else {
+			in = append(in, value.Convert(m.typ.In(i)))
+		}
+		words = rest
+	}
+
+	if len(words) > 0 {
+		return nil, errors.New("too many arguments, usage: " + m.usage())
+	}
+
+	for i, out := range reflect.ValueOf(c).MethodByName(m.name).Call(in) {
+		if m.typ.Out(i) == errorType {
+			if !out.IsNil() {
+				return nil, out.Interface().(error)
+			}
+			continue
+		}
+		results = append(results, out.Interface())
+	}
+	return results, nil
+}
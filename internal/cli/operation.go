@@ -0,0 +1,352 @@
+// Package cli implements the parts shared by the command-line tools:
+// reading and writing collections in several formats, and chaining collection operations.
+package cli
+
+import (
+	"fmt"
+	"github.com/habibimustafa/collection"
+	"reflect"
+	gosort "sort"
+	"strconv"
+	"strings"
+)
+
+// Operation is a collection operation that can be chained from the command line
+type Operation struct {
+	// Name is the name used on the command line
+	Name string
+
+	// Args is the names of the arguments that follow the name
+	Args []string
+
+	// Usage describes the operation
+	Usage string
+
+	apply func(c collection.Collection, args []string) (interface{}, error)
+}
+
+// Step is an operation with its arguments
+type Step struct {
+	Operation Operation
+	Args      []string
+}
+
+var operations = []Operation{
+	{
+		Name:  "filter",
+		Args:  []string{"path"},
+		Usage: "keep the items whose value at the path is present and not empty, false, or zero",
+		apply: func(c collection.Collection, args []string) (interface{}, error) {
+			return keepList(c, c.Filter(func(value interface{}, key interface{}, index int) bool {
+				found, ok := collection.Lookup(value, args[0])
+				return ok && truthy(found)
+			})), nil
+		},
+	},
+	{
+		Name:  "where",
+		Args:  []string{"path", "operator", "value"},
+		Usage: "keep the items whose value at the path compares to the value, operators are = != > >= < <= contains",
+		apply: func(c collection.Collection, args []string) (interface{}, error) {
+			compare, err := comparison(args[1])
+			if err != nil {
+				return nil, err
+			}
+
+			return keepList(c, c.Where(func(value interface{}, key interface{}, index int) bool {
+				found, ok := collection.Lookup(value, args[0])
+				return ok && compare(found, args[2])
+			})), nil
+		},
+	},
+	{
+		Name:  "sort-by",
+		Args:  []string{"path"},
+		Usage: "sort the items by their value at the path, a leading - sorts from the largest",
+		apply: func(c collection.Collection, args []string) (interface{}, error) {
+			path := strings.TrimPrefix(args[0], "-")
+			by := func(value interface{}, key interface{}, index int) interface{} {
+				found, _ := collection.Lookup(value, path)
+				return found
+			}
+
+			if strings.HasPrefix(args[0], "-") {
+				return keepList(c, c.SortByDesc(by)), nil
+			}
+			return keepList(c, c.SortBy(by)), nil
+		},
+	},
+	{
+		Name:  "group-by",
+		Args:  []string{"path"},
+		Usage: "group the items by their value at the path",
+		apply: func(c collection.Collection, args []string) (interface{}, error) {
+			return keepLists(c, c.GroupBy(func(value interface{}, key interface{}, index int) interface{} {
+				found, _ := collection.Lookup(value, args[0])
+				return found
+			})), nil
+		},
+	},
+	{
+		Name:  "pluck",
+		Args:  []string{"path"},
+		Usage: "get the value at the path of each item",
+		apply: func(c collection.Collection, args []string) (interface{}, error) {
+			return keepList(c, c.Pluck(args[0])), nil
+		},
+	},
+	{
+		Name:  "only",
+		Args:  []string{"keys"},
+		Usage: "keep the items with the comma separated keys",
+		apply: func(c collection.Collection, args []string) (interface{}, error) {
+			return c.Only(keysOf(c, args[0])...), nil
+		},
+	},
+	{
+		Name:  "except",
+		Args:  []string{"keys"},
+		Usage: "remove the items with the comma separated keys",
+		apply: func(c collection.Collection, args []string) (interface{}, error) {
+			return c.Except(keysOf(c, args[0])...), nil
+		},
+	},
+	{
+		Name:  "chunk",
+		Args:  []string{"size"},
+		Usage: "split the items into collections of the size",
+		apply: func(c collection.Collection, args []string) (interface{}, error) {
+			size, err := strconv.Atoi(args[0])
+			if err != nil {
+				return nil, fmt.Errorf("the chunk size %q is not a number", args[0])
+			}
+			return keepLists(c, c.Chunk(size)), nil
+		},
+	},
+	{
+		Name:  "sum",
+		Usage: "add up the numeric values, it must be the last operation",
+		apply: func(c collection.Collection, args []string) (interface{}, error) {
+			return c.Sum(), nil
+		},
+	},
+}
+
+// Operations lists the operations sorted by name
+func Operations() []Operation {
+	sorted := append([]Operation{}, operations...)
+	gosort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	return sorted
+}
+
+// Parse splits the arguments into steps, each operation name is followed by its arguments
+func Parse(args []string) ([]Step, error) {
+	var steps []Step
+	for len(args) > 0 {
+		operation, ok := lookupOperation(args[0])
+		if !ok {
+			return nil, fmt.Errorf("unknown operation %q", args[0])
+		}
+
+		if len(args)-1 < len(operation.Args) {
+			return nil, fmt.Errorf("operation %q needs %d arguments: %s", operation.Name, len(operation.Args), strings.Join(operation.Args, ", "))
+		}
+
+		steps = append(steps, Step{Operation: operation, Args: args[1 : 1+len(operation.Args)]})
+		args = args[1+len(operation.Args):]
+	}
+	return steps, nil
+}
+
+func lookupOperation(name string) (Operation, bool) {
+	for _, operation := range operations {
+		if operation.Name == name {
+			return operation, true
+		}
+	}
+	return Operation{}, false
+}
+
+// Run applies the steps in order. The result is a collection, or a number when the last step is sum.
+// Panics of the collection methods are returned as errors.
+func Run(c collection.Collection, steps []Step) (result interface{}, err error) {
+	result = c
+	for i, step := range steps {
+		current, ok := result.(collection.Collection)
+		if !ok {
+			return nil, fmt.Errorf("operation %q cannot follow %q, it needs a collection", step.Operation.Name, steps[i-1].Operation.Name)
+		}
+
+		if result, err = apply(current, step); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func apply(c collection.Collection, step Step) (result interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = nil, fmt.Errorf("%s: %v", step.Operation.Name, r)
+		}
+	}()
+
+	result, err = step.Operation.apply(c, step.Args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", step.Operation.Name, err)
+	}
+	return result, nil
+}
+
+// keepList reindexes the result when the collection is keyed by the positions of its items,
+// so lists stay lists after removing or moving items
+func keepList(c collection.Collection, result collection.Collection) collection.Collection {
+	if isList(c) {
+		return result.Reindex()
+	}
+	return result
+}
+
+// keepLists reindexes the collections of the result when the collection is keyed by the positions of its items
+func keepLists(c collection.Collection, result collection.Collection) collection.Collection {
+	if !isList(c) {
+		return result
+	}
+
+	return result.MapValues(func(value interface{}, key interface{}, index int) interface{} {
+		return value.(collection.Collection).Reindex()
+	})
+}
+
+// isList is the collection keyed by the positions of its items
+func isList(c collection.Collection) bool {
+	for i, key := range c.Keys() {
+		if key != i {
+			return false
+		}
+	}
+	return true
+}
+
+// keysOf finds the keys of the collection whose formatted value is one of the comma separated keys
+func keysOf(c collection.Collection, list string) []interface{} {
+	wanted := map[string]bool{}
+	for _, key := range strings.Split(list, ",") {
+		wanted[strings.TrimSpace(key)] = true
+	}
+
+	var keys []interface{}
+	for _, key := range c.Keys() {
+		if wanted[fmt.Sprint(key)] {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// truthy is the value not nil, false, zero, or empty
+func truthy(value interface{}) bool {
+	if value == nil {
+		return false
+	}
+
+	if c, ok := value.(collection.Collection); ok {
+		return c.Size() > 0
+	}
+
+	val := reflect.ValueOf(value)
+	switch val.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Array, reflect.String:
+		return val.Len() > 0
+	default:
+		return !val.IsZero()
+	}
+}
+
+// comparison gets the function comparing a value with the argument of a where operation.
+// Values are compared as numbers when both are numbers, otherwise as formatted strings.
+func comparison(operator string) (func(value interface{}, arg string) bool, error) {
+	var accept func(order int) bool
+	switch operator {
+	case "=", "==":
+		accept = func(order int) bool { return order == 0 }
+	case "!=":
+		accept = func(order int) bool { return order != 0 }
+	case ">":
+		accept = func(order int) bool { return order > 0 }
+	case ">=":
+		accept = func(order int) bool { return order >= 0 }
+	case "<":
+		accept = func(order int) bool { return order < 0 }
+	case "<=":
+		accept = func(order int) bool { return order <= 0 }
+	case "contains":
+		return contains, nil
+	default:
+		return nil, fmt.Errorf("unknown operator %q", operator)
+	}
+
+	return func(value interface{}, arg string) bool {
+		return accept(order(value, arg))
+	}, nil
+}
+
+func order(value interface{}, arg string) int {
+	if n, ok := number(value); ok {
+		if m, err := strconv.ParseFloat(arg, 64); err == nil {
+			switch {
+			case n < m:
+				return -1
+			case n > m:
+				return 1
+			default:
+				return 0
+			}
+		}
+	}
+	return strings.Compare(format(value), arg)
+}
+
+// contains is the formatted value containing the argument,
+// or for collections and slices, one of the items formatted as the argument
+func contains(value interface{}, arg string) bool {
+	if c, ok := value.(collection.Collection); ok {
+		value = c.Values().All()
+	}
+
+	val := reflect.ValueOf(value)
+	if val.Kind() == reflect.Slice || val.Kind() == reflect.Array {
+		for i := 0; i < val.Len(); i++ {
+			if format(val.Index(i).Interface()) == arg {
+				return true
+			}
+		}
+		return false
+	}
+	return strings.Contains(format(value), arg)
+}
+
+func number(value interface{}) (float64, bool) {
+	val := reflect.ValueOf(value)
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(val.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(val.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return val.Float(), true
+	}
+	return 0, false
+}
+
+// format formats a value for comparisons and text output, nil is empty
+func format(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,369 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"github.com/habibimustafa/collection"
+	"io"
+	"os"
+	"path/filepath"
+	gosort "sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+)
+
+// ErrQuit is returned by Session.Exec when the session should end
+var ErrQuit = errors.New("quit")
+
+// Session keeps the current collection of an interactive shell and the steps that led to it.
+// Lines starting with a method name of Collection call the method on the current collection,
+// a result that is a collection becomes the current one and other results are printed.
+type Session struct {
+	out     io.Writer
+	values  []collection.Collection
+	steps   []string
+	methods map[string]method
+}
+
+// NewSession starts a session with an empty collection, printing to out
+func NewSession(out io.Writer) *Session {
+	return &Session{
+		out:     out,
+		values:  []collection.Collection{collection.Collect(nil)},
+		steps:   []string{"start"},
+		methods: methods(),
+	}
+}
+
+// Current gets the current collection
+func (s *Session) Current() collection.Collection {
+	return s.values[len(s.values)-1]
+}
+
+type command struct {
+	usage string
+	help  string
+	run   func(s *Session, args []token) error
+}
+
+var commands map[string]command
+
+func init() {
+	commands = map[string]command{
+		"load": {"load FILE [FORMAT]", "read a file into the current collection", func(s *Session, args []token) error {
+			if len(args) < 1 || len(args) > 2 {
+				return errors.New("usage: load FILE [FORMAT]")
+			}
+			return s.Load(args[0].text, formatArg(args))
+		}},
+		"export": {"export FILE [FORMAT]", "write the current collection to a file", func(s *Session, args []token) error {
+			if len(args) < 1 || len(args) > 2 {
+				return errors.New("usage: export FILE [FORMAT]")
+			}
+			return s.export(args[0].text, formatArg(args))
+		}},
+		"show": {"show [N]", "preview the first N items as a table, 10 by default", func(s *Session, args []token) error {
+			rows := 10
+			if len(args) > 0 {
+				n, err := strconv.Atoi(args[0].text)
+				if err != nil || n < 0 {
+					return fmt.Errorf("the number of rows %q is not a positive number", args[0].text)
+				}
+				rows = n
+			}
+			return Preview(s.out, s.Current(), rows)
+		}},
+		"undo": {"undo", "go back to the collection before the last step", func(s *Session, args []token) error {
+			if len(s.values) == 1 {
+				return errors.New("nothing to undo")
+			}
+			s.values, s.steps = s.values[:len(s.values)-1], s.steps[:len(s.steps)-1]
+			s.summary()
+			return nil
+		}},
+		"history": {"history", "list the steps that led to the current collection", func(s *Session, args []token) error {
+			for i, step := range s.steps {
+				fmt.Fprintf(s.out, "%3d  %s\n", i, step)
+			}
+			return nil
+		}},
+		"help": {"help [METHOD]", "list the commands and methods, or describe a method", func(s *Session, args []token) error {
+			return s.help(args)
+		}},
+		"quit": {"quit", "leave the shell", func(s *Session, args []token) error {
+			return ErrQuit
+		}},
+	}
+}
+
+func formatArg(args []token) string {
+	if len(args) > 1 {
+		return args[1].text
+	}
+	return FormatOf(args[0].text)
+}
+
+// Load reads a file into the current collection
+func (s *Session) Load(path string, format string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	c, err := Read(f, format)
+	if err != nil {
+		return err
+	}
+	s.push(c, "load "+path)
+	return nil
+}
+
+func (s *Session) export(path string, format string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := Write(f, s.Current(), format, true); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (s *Session) push(c collection.Collection, step string) {
+	s.values = append(s.values, c)
+	s.steps = append(s.steps, step)
+	s.summary()
+}
+
+func (s *Session) summary() {
+	if size := s.Current().Size(); size == 1 {
+		fmt.Fprintln(s.out, "1 item")
+	} else {
+		fmt.Fprintf(s.out, "%d items\n", size)
+	}
+}
+
+// Exec runs a line of input, it returns ErrQuit when the line ends the session
+func (s *Session) Exec(line string) error {
+	tokens, err := tokenize(line)
+	if err != nil || len(tokens) == 0 {
+		return err
+	}
+
+	name := tokens[0].text
+	if cmd, ok := commands[name]; ok {
+		return cmd.run(s, tokens[1:])
+	}
+
+	m, ok := s.methods[name]
+	if !ok {
+		return fmt.Errorf("unknown command or method %q, try help", name)
+	}
+
+	results, err := m.call(s.Current(), tokens[1:])
+	if err != nil {
+		return err
+	}
+
+	if len(results) == 1 {
+		if c, ok := results[0].(collection.Collection); ok {
+			s.push(c, strings.TrimSpace(line))
+			return nil
+		}
+	}
+
+	for _, result := range results {
+		if err := Write(s.out, display(result), "json", false); err != nil {
+			fmt.Fprintln(s.out, result)
+		}
+	}
+	return nil
+}
+
+// display turns items returned as maps into collections, which encode as JSON
+func display(value interface{}) interface{} {
+	if m, ok := value.(map[interface{}]interface{}); ok {
+		return collection.Collect(m)
+	}
+	return value
+}
+
+func (s *Session) help(args []token) error {
+	if len(args) > 0 {
+		if cmd, ok := commands[args[0].text]; ok {
+			fmt.Fprintf(s.out, "%s\n    %s\n", cmd.usage, cmd.help)
+			return nil
+		}
+
+		m, ok := s.methods[args[0].text]
+		if !ok {
+			return fmt.Errorf("unknown command or method %q", args[0].text)
+		}
+		fmt.Fprintf(s.out, "%s\n    %s%s\n", m.usage(), m.name, strings.TrimPrefix(m.typ.String(), "func"))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(s.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "commands:")
+	for _, name := range commandNames() {
+		fmt.Fprintf(w, "  %s\t%s\n", commands[name].usage, commands[name].help)
+	}
+
+	fmt.Fprintln(w, "methods:")
+	for _, name := range methodNames(s.methods) {
+		fmt.Fprintf(w, "  %s\n", s.methods[name].usage())
+	}
+	fmt.Fprintln(w, "paths are dot paths into the items, \".\" is the item itself, and operators are = != > >= < <= contains")
+	return w.Flush()
+}
+
+// Complete completes the word before the cursor with a command or method name at the start of the line,
+// or a file name after load and export. The candidates are returned when there are several.
+func (s *Session) Complete(line string, pos int) (string, int, []string) {
+	before := line[:pos]
+	start := strings.LastIndexAny(before, " \t") + 1
+	word := before[start:]
+
+	var names []string
+	if strings.TrimSpace(before[:start]) == "" {
+		names = append(commandNames(), methodNames(s.methods)...)
+	} else if first := strings.Fields(before)[0]; first == "load" || first == "export" {
+		names, _ = filepath.Glob(word + "*")
+	}
+
+	var matches []string
+	for _, name := range names {
+		if strings.HasPrefix(name, word) {
+			matches = append(matches, name)
+		}
+	}
+
+	if len(matches) == 0 {
+		return line, pos, nil
+	}
+
+	completed := matches[0]
+	for _, match := range matches[1:] {
+		completed = commonPrefix(completed, match)
+	}
+
+	if len(matches) == 1 {
+		completed += " "
+	}
+
+	newLine := before[:start] + completed + line[pos:]
+	if len(matches) == 1 {
+		return newLine, start + len(completed), nil
+	}
+	return newLine, start + len(completed), matches
+}
+
+func commonPrefix(a string, b string) string {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[:i]
+		}
+	}
+	if len(a) < len(b) {
+		return a
+	}
+	return b
+}
+
+// Preview writes the first rows of the collection as a table.
+// Items that are all collections get a column for each of their keys, other items a value column.
+func Preview(w io.Writer, c collection.Collection, rows int) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	keys, values := c.Keys(), c.Values()
+	shown := c.Size()
+	if shown > rows {
+		shown = rows
+	}
+
+	cells, header, ok := table(c)
+	if ok {
+		fmt.Fprintln(tw, "key\t"+strings.Join(header, "\t"))
+		for i := 0; i < shown; i++ {
+			record := []string{truncate(cell(keys[i]))}
+			for _, column := range header {
+				record = append(record, truncate(cell(cells[i][column])))
+			}
+			fmt.Fprintln(tw, strings.Join(record, "\t"))
+		}
+	} else {
+		fmt.Fprintln(tw, "key\tvalue")
+		for i := 0; i < shown; i++ {
+			fmt.Fprintf(tw, "%s\t%s\n", truncate(cell(keys[i])), truncate(cell(values[i])))
+		}
+	}
+
+	if more := c.Size() - shown; more > 0 {
+		fmt.Fprintf(tw, "... %d more\n", more)
+	}
+	return tw.Flush()
+}
+
+func truncate(text string) string {
+	const width = 40
+	text = strings.NewReplacer("\t", " ", "\n", " ").Replace(text)
+	if utf8.RuneCountInString(text) <= width {
+		return text
+	}
+	return string([]rune(text)[:width-3]) + "..."
+}
+
+// token is a word of a line, quoted words are always strings
+type token struct {
+	text   string
+	quoted bool
+}
+
+// tokenize splits a line into words separated by spaces, single or double quotes keep spaces in a word
+func tokenize(line string) ([]token, error) {
+	var tokens []token
+	var current strings.Builder
+	var quote rune
+	inWord, quoted := false, false
+
+	for _, r := range line {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			current.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote, inWord, quoted = r, true, true
+		case r == ' ' || r == '\t':
+			if inWord {
+				tokens = append(tokens, token{current.String(), quoted})
+				current.Reset()
+				inWord, quoted = false, false
+			}
+		default:
+			current.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inWord {
+		tokens = append(tokens, token{current.String(), quoted})
+	}
+	return tokens, nil
+}
+
+func commandNames() []string {
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	gosort.Strings(names)
+	return names
+}
@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func session(t *testing.T) (*Session, *bytes.Buffer) {
+	path := filepath.Join(t.TempDir(), "users.csv")
+	assert.NoError(t, os.WriteFile(path, []byte(users), 0o600))
+
+	var out bytes.Buffer
+	s := NewSession(&out)
+	assert.NoError(t, s.Load(path, "csv"))
+	out.Reset()
+	return s, &out
+}
+
+func TestSessionMethods(t *testing.T) {
+	s, out := session(t)
+
+	assert.NoError(t, s.Exec("Filter age >= 18"))
+	assert.Equal(t, []interface{}{0, 2}, s.Current().Keys().All())
+
+	assert.NoError(t, s.Exec("GroupBy city"))
+	assert.Equal(t, []interface{}{"Oslo"}, s.Current().Keys().All())
+
+	assert.NoError(t, s.Exec("undo"))
+	assert.NoError(t, s.Exec("Only 2"))
+	assert.NoError(t, s.Exec("Map name"))
+	assert.Equal(t, []interface{}{"Cid"}, s.Current().Values().All())
+
+	assert.NoError(t, s.Exec(`Contains 2 "Cid"`))
+	assert.NoError(t, s.Exec("Size"))
+	assert.Equal(t, "2 items\n1 item\n2 items\n1 item\n1 item\ntrue\n1\n", out.String())
+}
+
+func TestSessionCommands(t *testing.T) {
+	s, out := session(t)
+
+	assert.NoError(t, s.Exec("Where city = Rome"))
+	assert.NoError(t, s.Exec("show"))
+	assert.NoError(t, s.Exec("history"))
+	assert.Equal(t, "1 item\n"+
+		"key  name  age  city\n"+
+		"1    Bob   15   Rome\n"+
+		"  0  start\n"+
+		"  1  "+s.steps[1]+"\n"+
+		"  2  Where city = Rome\n", out.String())
+
+	assert.NoError(t, s.Exec("undo"))
+	assert.NoError(t, s.Exec("undo"))
+	assert.EqualError(t, s.Exec("undo"), "nothing to undo")
+	assert.Equal(t, 0, s.Current().Size())
+	assert.Equal(t, ErrQuit, s.Exec("quit"))
+
+	s, out = session(t)
+	path := filepath.Join(t.TempDir(), "adults.ndjson")
+	assert.NoError(t, s.Exec("Where age > 18"))
+	assert.NoError(t, s.Exec("export "+path))
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"name\":\"Ann\",\"age\":30,\"city\":\"Oslo\"}\n{\"name\":\"Cid\",\"age\":22.5,\"city\":\"Oslo\"}\n", string(data))
+
+	out.Reset()
+	assert.NoError(t, s.Exec("show 1"))
+	assert.Equal(t, "key  name  age  city\n0    Ann   30   Oslo\n... 1 more\n", out.String())
+}
+
+func TestSessionErrors(t *testing.T) {
+	s, _ := session(t)
+
+	assert.EqualError(t, s.Exec("Shuffle"), `unknown command or method "Shuffle", try help`)
+	assert.EqualError(t, s.Exec("Chunk"), "usage: Chunk INT")
+	assert.EqualError(t, s.Exec("Chunk two"), `"two" is not a valid int`)
+	assert.EqualError(t, s.Exec("Chunk 2 3"), "too many arguments, usage: Chunk INT")
+	assert.EqualError(t, s.Exec("Histogram 0"), "Histogram: the number of buckets must be greater than zero")
+	assert.EqualError(t, s.Exec("Sole city = Oslo"), "more than one item matches")
+	assert.EqualError(t, s.Exec(`Only "name`), "unterminated \" quote")
+	assert.Equal(t, 3, s.Current().Size())
+}
+
+func TestSessionComplete(t *testing.T) {
+	s := NewSession(&bytes.Buffer{})
+
+	line, pos, candidates := s.Complete("Gro", 3)
+	assert.Equal(t, "GroupBy ", line)
+	assert.Equal(t, 8, pos)
+	assert.Nil(t, candidates)
+
+	line, pos, candidates = s.Complete("Fil x", 3)
+	assert.Equal(t, "Filter  x", line)
+	assert.Equal(t, 7, pos)
+	assert.Nil(t, candidates)
+
+	line, pos, candidates = s.Complete("Sort", 4)
+	assert.Equal(t, "SortBy", line)
+	assert.Equal(t, 6, pos)
+	assert.Equal(t, []string{"SortBy", "SortByDesc"}, candidates)
+
+	line, _, candidates = s.Complete("Filter Gro", 10)
+	assert.Equal(t, "Filter Gro", line)
+	assert.Nil(t, candidates)
+
+	_, _, candidates = s.Complete("h", 1)
+	assert.Equal(t, []string{"help", "history"}, candidates)
+}
+
+func TestSessionHelp(t *testing.T) {
+	var out bytes.Buffer
+	s := NewSession(&out)
+
+	assert.NoError(t, s.Exec("help Filter"))
+	assert.Equal(t, "Filter PATH [OP VALUE]\n    Filter(func(interface {}, interface {}, int) bool) collection.Collection\n", out.String())
+
+	_, ok := s.methods["Shuffle"]
+	assert.False(t, ok, "methods with parameters that cannot be typed are left out")
+	_, ok = s.methods["Match"]
+	assert.False(t, ok)
+}
+
+func TestTokenize(t *testing.T) {
+	tokens, err := tokenize(`Where  name = "Ann Lee" 'x'`)
+	assert.NoError(t, err)
+	assert.Equal(t, []token{{"Where", false}, {"name", false}, {"=", false}, {"Ann Lee", true}, {"x", true}}, tokens)
+}
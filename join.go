@@ -0,0 +1,114 @@
+package collection
+
+import (
+	"github.com/habibimustafa/collection/arr"
+	"github.com/habibimustafa/collection/deep"
+)
+
+// Join combines the items of both collections whose join keys are equal, like an SQL inner join.
+//
+// The join keys are the callback results of each side, numbers of different types with the same
+// value are equal and nil keys never match. The other collection is indexed by hash, so each item
+// is only compared with the items sharing its hash. The combined values are keyed by their position,
+// following the order of this collection, then the order of the other collection for the same item.
+func (c collect) Join(other Collection, leftKey func(value interface{}, key interface{}, index int) interface{}, rightKey func(value interface{}, key interface{}, index int) interface{}, combine func(left interface{}, right interface{}) interface{}) Collection {
+	return c.join(other, leftKey, rightKey, combine, false, false)
+}
+
+// LeftJoin is like Join, but also keeps the items of this collection without a match,
+// they are combined with nil
+func (c collect) LeftJoin(other Collection, leftKey func(value interface{}, key interface{}, index int) interface{}, rightKey func(value interface{}, key interface{}, index int) interface{}, combine func(left interface{}, right interface{}) interface{}) Collection {
+	return c.join(other, leftKey, rightKey, combine, true, false)
+}
+
+// RightJoin is like Join, but also keeps the items of the other collection without a match,
+// they are combined with nil and come after the matched items
+func (c collect) RightJoin(other Collection, leftKey func(value interface{}, key interface{}, index int) interface{}, rightKey func(value interface{}, key interface{}, index int) interface{}, combine func(left interface{}, right interface{}) interface{}) Collection {
+	return c.join(other, leftKey, rightKey, combine, false, true)
+}
+
+// FullJoin is like Join, but also keeps the items of both collections without a match,
+// they are combined with nil and the ones of the other collection come last
+func (c collect) FullJoin(other Collection, leftKey func(value interface{}, key interface{}, index int) interface{}, rightKey func(value interface{}, key interface{}, index int) interface{}, combine func(left interface{}, right interface{}) interface{}) Collection {
+	return c.join(other, leftKey, rightKey, combine, true, true)
+}
+
+// GroupJoin combines each item with all the items of the other collection sharing its join key,
+// in the order of the other collection. The result keeps the keys of this collection.
+func (c collect) GroupJoin(other Collection, leftKey func(value interface{}, key interface{}, index int) interface{}, rightKey func(value interface{}, key interface{}, index int) interface{}, combine func(left interface{}, rights arr.Array) interface{}) Collection {
+	index := newJoinIndex(other, rightKey)
+	return c.Map(func(value interface{}, key interface{}, i int) (interface{}, interface{}) {
+		rights := arr.Array{}
+		for _, j := range index.lookup(leftKey(value, key, i)) {
+			rights = append(rights, index.values[j])
+		}
+		return combine(value, rights), key
+	})
+}
+
+func (c collect) join(other Collection, leftKey func(value interface{}, key interface{}, index int) interface{}, rightKey func(value interface{}, key interface{}, index int) interface{}, combine func(left interface{}, right interface{}) interface{}, keepLeft bool, keepRight bool) Collection {
+	index := newJoinIndex(other, rightKey)
+	matched := make([]bool, len(index.values))
+
+	joined := collect{}
+	for i := range c.keys {
+		matches := index.lookup(leftKey(c.values[i], c.keys[i], i))
+		for _, j := range matches {
+			matched[j] = true
+			joined = joined.push(combine(c.values[i], index.values[j]))
+		}
+
+		if len(matches) == 0 && keepLeft {
+			joined = joined.push(combine(c.values[i], nil))
+		}
+	}
+
+	if keepRight {
+		for j, value := range index.values {
+			if !matched[j] {
+				joined = joined.push(combine(nil, value))
+			}
+		}
+	}
+	return joined
+}
+
+// joinIndex is a hash index of the collection values by their join keys
+type joinIndex struct {
+	values  []interface{}
+	keys    []interface{}
+	buckets map[uint64][]int
+}
+
+func newJoinIndex(c Collection, keyFn func(value interface{}, key interface{}, index int) interface{}) joinIndex {
+	index := joinIndex{
+		values:  c.Values().All(),
+		keys:    make([]interface{}, c.Size()),
+		buckets: map[uint64][]int{},
+	}
+
+	for i, key := range c.Keys() {
+		joinKey := keyFn(index.values[i], key, i)
+		index.keys[i] = joinKey
+		if joinKey != nil {
+			hash := deep.HashNumeric(joinKey)
+			index.buckets[hash] = append(index.buckets[hash], i)
+		}
+	}
+	return index
+}
+
+// lookup gets the positions of the values whose join key equals the given key
+func (index joinIndex) lookup(key interface{}) []int {
+	if key == nil {
+		return nil
+	}
+
+	var matches []int
+	for _, i := range index.buckets[deep.HashNumeric(key)] {
+		if deep.EqualNumeric(index.keys[i], key) {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
@@ -0,0 +1,106 @@
+package collection
+
+import (
+	"github.com/habibimustafa/collection/arr"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var users = []map[string]interface{}{
+	{"id": 1, "name": "Ann"},
+	{"id": 2, "name": "Bob"},
+	{"id": 3, "name": "Cid"},
+}
+
+var orders = []map[string]interface{}{
+	{"user": 2, "item": "pen"},
+	{"user": int64(1), "item": "ink"},
+	{"user": 2.0, "item": "cap"},
+	{"user": 9, "item": "box"},
+	{"user": nil, "item": "bag"},
+}
+
+func byField(name string) func(value interface{}, key interface{}, index int) interface{} {
+	return func(value interface{}, key interface{}, index int) interface{} {
+		return value.(map[string]interface{})[name]
+	}
+}
+
+func pair(left interface{}, right interface{}) interface{} {
+	var name, item interface{}
+	if left != nil {
+		name = left.(map[string]interface{})["name"]
+	}
+	if right != nil {
+		item = right.(map[string]interface{})["item"]
+	}
+	return arr.Array{name, item}
+}
+
+func TestCollectionJoin(t *testing.T) {
+	joined := Collect(users).Join(Collect(orders), byField("id"), byField("user"), pair)
+
+	assert.Equal(t, []interface{}{0, 1, 2}, joined.Keys().All())
+	assert.Equal(t, []interface{}{
+		arr.Array{"Ann", "ink"},
+		arr.Array{"Bob", "pen"},
+		arr.Array{"Bob", "cap"},
+	}, joined.Values().All())
+}
+
+func TestCollectionOuterJoins(t *testing.T) {
+	left := Collect(users).LeftJoin(Collect(orders), byField("id"), byField("user"), pair)
+	assert.Equal(t, 4, left.Size())
+	assert.Equal(t, arr.Array{"Cid", nil}, left.Values().Last())
+
+	right := Collect(users).RightJoin(Collect(orders), byField("id"), byField("user"), pair)
+	assert.Equal(t, []interface{}{
+		arr.Array{"Ann", "ink"},
+		arr.Array{"Bob", "pen"},
+		arr.Array{"Bob", "cap"},
+		arr.Array{nil, "box"},
+		arr.Array{nil, "bag"},
+	}, right.Values().All())
+
+	full := Collect(users).FullJoin(Collect(orders), byField("id"), byField("user"), pair)
+	assert.Equal(t, []interface{}{
+		arr.Array{"Ann", "ink"},
+		arr.Array{"Bob", "pen"},
+		arr.Array{"Bob", "cap"},
+		arr.Array{"Cid", nil},
+		arr.Array{nil, "box"},
+		arr.Array{nil, "bag"},
+	}, full.Values().All())
+}
+
+func TestCollectionGroupJoin(t *testing.T) {
+	grouped := Collect(users).GroupJoin(Collect(orders), byField("id"), byField("user"), func(left interface{}, rights arr.Array) interface{} {
+		return rights.Size()
+	})
+
+	assert.Equal(t, []interface{}{0, 1, 2}, grouped.Keys().All())
+	assert.Equal(t, []interface{}{1, 2, 0}, grouped.Values().All())
+}
+
+func TestCollectionJoinLarge(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping large join in short mode")
+	}
+
+	const size = 100000
+	left := make([]int, size)
+	right := make([]int, size)
+	for i := 0; i < size; i++ {
+		left[i] = i
+		right[i] = size - 1 - i
+	}
+
+	identity := func(value interface{}, key interface{}, index int) interface{} { return value }
+	joined := Collect(left).Join(Collect(right), identity, identity, func(left interface{}, right interface{}) interface{} {
+		return left
+	})
+
+	assert.Equal(t, size, joined.Size())
+	assert.Equal(t, 0, joined.Values().First())
+	assert.Equal(t, size-1, joined.Values().Last())
+}
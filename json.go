@@ -0,0 +1,111 @@
+package collection
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// MarshalJSON encodes the collection as a JSON object keeping the order of the items,
+// keys are formatted with fmt.Sprint and nested collections are encoded the same way
+func (c collect) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i := range c.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(fmt.Sprint(c.keys[i]))
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+
+		value, err := json.Marshal(c.values[i])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes a JSON object or array into the collection, see FromJSON
+func (c *collect) UnmarshalJSON(data []byte) error {
+	decoded, err := FromJSON(data)
+	if err != nil {
+		return err
+	}
+
+	*c = decoded.(collect)
+	return nil
+}
+
+// FromJSON decodes a JSON object into a collection keeping the order of the keys in the document.
+// Nested objects become collections, arrays become []interface{}, and numbers become float64
+// like encoding/json. A top-level array becomes a collection keyed by the positions of its items.
+// Repeated keys keep the position of their first appearance and their last value.
+func FromJSON(data []byte) (Collection, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	value, err := readJSON(dec)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("collection: invalid JSON: unexpected data after the top-level value")
+	}
+
+	switch v := value.(type) {
+	case collect:
+		return v, nil
+	case []interface{}:
+		return Collect(v), nil
+	default:
+		return nil, fmt.Errorf("collection: cannot decode JSON %T into a collection", value)
+	}
+}
+
+func readJSON(dec *json.Decoder) (interface{}, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("collection: invalid JSON: %w", err)
+	}
+
+	switch token {
+	case json.Delim('{'):
+		c := collect{}.build()
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, fmt.Errorf("collection: invalid JSON: %w", err)
+			}
+
+			value, err := readJSON(dec)
+			if err != nil {
+				return nil, err
+			}
+			c.put(key, value)
+		}
+		_, err = dec.Token()
+		return c.collect, err
+	case json.Delim('['):
+		items := []interface{}{}
+		for dec.More() {
+			item, err := readJSON(dec)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		_, err = dec.Token()
+		return items, err
+	default:
+		return token, nil
+	}
+}
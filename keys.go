@@ -0,0 +1,229 @@
+package collection
+
+import (
+	"github.com/habibimustafa/collection/arr"
+	"github.com/habibimustafa/collection/deep"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// Hasher gets a hash of a key, keys that are equal must have the same hash
+type Hasher interface {
+	Hash(key interface{}) uint64
+}
+
+// KeyEqualer decides when two keys refer to the same item.
+// A collection with a KeyEqualer uses it to find keys in Has, GetValue, Contains, Set,
+// Append, Prepend, Only, Except and Unset instead of the == operator, and allows mixing key types.
+// The hash finds the keys when many of them are looked up at once, such as in Collect, Has, Only and Except.
+type KeyEqualer interface {
+	Hasher
+
+	// Equal reports whether both keys refer to the same item
+	Equal(a, b interface{}) bool
+}
+
+// Option configures a collection created by Collect
+type Option func(c *collect)
+
+// WithKeyEqualer uses the equaler to compare the collection keys
+func WithKeyEqualer(equaler KeyEqualer) Option {
+	return func(c *collect) {
+		c.equaler = equaler
+	}
+}
+
+// NumericKeys compares numeric keys by value, so int(1), int64(1) and float64(1) are the same key
+type NumericKeys struct{}
+
+// Equal reports whether both keys hold the same value
+func (NumericKeys) Equal(a, b interface{}) bool {
+	return deep.EqualNumeric(a, b)
+}
+
+// Hash gets a hash of the key value
+func (NumericKeys) Hash(key interface{}) uint64 {
+	return deep.HashNumeric(key)
+}
+
+// DeepKeys compares keys deeply, so slices, maps and structs holding them can be used as keys
+type DeepKeys struct{}
+
+// Equal reports whether both keys are deeply equal
+func (DeepKeys) Equal(a, b interface{}) bool {
+	return deep.Equal(a, b)
+}
+
+// Hash gets a hash of the key content
+func (DeepKeys) Hash(key interface{}) uint64 {
+	return deep.Hash(key)
+}
+
+// indexOf gets the position of the key, or -1 when the key is not exist
+func (c collect) indexOf(key interface{}) int {
+	if c.equaler == nil {
+		for i, k := range c.keys {
+			if k == key {
+				return i
+			}
+		}
+		return -1
+	}
+
+	for i, k := range c.keys {
+		if c.equaler.Equal(k, key) {
+			return i
+		}
+	}
+	return -1
+}
+
+// builder adds items to a collection, finding its keys by their hash instead of scanning all of them.
+// Keys are hashed with the key equaler, or with deep.Hash which is consistent with == when there is none.
+type builder struct {
+	collect
+	buckets map[uint64][]int
+}
+
+// build starts building on a copy of the items of the collection
+func (c collect) build() *builder {
+	keys, values := append([]interface{}{}, c.keys...), append([]interface{}{}, c.values...)
+	b := &builder{collect: c.with(keys, values), buckets: map[uint64][]int{}}
+	for i, key := range c.keys {
+		sum := b.hash(key)
+		b.buckets[sum] = append(b.buckets[sum], i)
+	}
+	return b
+}
+
+func (b *builder) hash(key interface{}) uint64 {
+	if b.equaler == nil {
+		return deep.Hash(key)
+	}
+	return b.equaler.Hash(key)
+}
+
+// indexOf gets the position of the key, or -1 when the key is not exist
+func (b *builder) indexOf(key interface{}) int {
+	for _, i := range b.buckets[b.hash(key)] {
+		if b.equaler == nil && b.keys[i] == key || b.equaler != nil && b.equaler.Equal(b.keys[i], key) {
+			return i
+		}
+	}
+	return -1
+}
+
+// check reports why the key cannot be added, like collect.checkKey
+func (b *builder) check(key interface{}) error {
+	if err := b.checkKeyType(key); err != nil {
+		return err
+	}
+
+	if b.indexOf(key) > -1 {
+		return errKeyExists
+	}
+	return nil
+}
+
+// add appends the item without checking its key
+func (b *builder) add(key interface{}, value interface{}) {
+	sum := b.hash(key)
+	b.buckets[sum] = append(b.buckets[sum], len(b.keys))
+	b.keys = append(b.keys, key)
+	b.values = append(b.values, value)
+}
+
+// put sets the value of an existing key, or adds the key to the end after validating it
+func (b *builder) put(key interface{}, value interface{}) {
+	if err := b.checkKeyType(key); err != nil {
+		panic(err.Error())
+	}
+
+	if index := b.indexOf(key); index > -1 {
+		b.values[index] = value
+		return
+	}
+	b.add(key, value)
+}
+
+// matcher gets a function that reports whether a key is one of the given keys
+func (c collect) matcher(keys []interface{}) func(key interface{}) bool {
+	if c.equaler == nil {
+		return func(key interface{}) bool {
+			return arr.List(keys).Has(key)
+		}
+	}
+
+	index := map[uint64][]interface{}{}
+	for _, key := range keys {
+		sum := c.equaler.Hash(key)
+		index[sum] = append(index[sum], key)
+	}
+
+	return func(key interface{}) bool {
+		for _, k := range index[c.equaler.Hash(key)] {
+			if c.equaler.Equal(k, key) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// with creates a collection of the keys and values that keeps the key equaler
+func (c collect) with(keys []interface{}, values []interface{}) collect {
+	return collect{keys: keys, values: values, equaler: c.equaler}
+}
+
+// WithKeyNormalizer compares string keys by their normalized form, the keys keep their original form
+func WithKeyNormalizer(normalize func(key string) string) Option {
+	return WithKeyEqualer(normalizedKeys{normalize: normalize})
+}
+
+// CollectFold collecting an array, slice, or map as a Collection object with case-insensitive string keys
+func CollectFold(collection interface{}, options ...Option) Collection {
+	return Collect(collection, append([]Option{WithKeyEqualer(foldKeys)}, options...)...)
+}
+
+// foldKeys is the key equaler of CollectFold
+var foldKeys = normalizedKeys{normalize: foldCase, fold: true}
+
+// normalizedKeys compares string keys after normalizing them, other keys are compared deeply
+type normalizedKeys struct {
+	normalize func(key string) string
+
+	// fold marks the case folding normalizer, the only normalizer the binary format can encode
+	fold bool
+}
+
+// Equal reports whether both keys have the same normalized form
+func (n normalizedKeys) Equal(a, b interface{}) bool {
+	return deep.Equal(n.normalized(a), n.normalized(b))
+}
+
+// Hash gets a hash of the normalized key
+func (n normalizedKeys) Hash(key interface{}) uint64 {
+	return deep.Hash(n.normalized(key))
+}
+
+func (n normalizedKeys) normalized(key interface{}) interface{} {
+	if val := reflect.ValueOf(key); val.Kind() == reflect.String {
+		return n.normalize(val.String())
+	}
+	return key
+}
+
+// foldCase maps every rune to the smallest rune of its case folding orbit,
+// so two strings have the same result when strings.EqualFold reports them equal
+func foldCase(key string) string {
+	return strings.Map(func(r rune) rune {
+		min := r
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			if f < min {
+				min = f
+			}
+		}
+		return min
+	}, key)
+}
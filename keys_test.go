@@ -0,0 +1,113 @@
+package collection
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+type point struct {
+	X, Y int
+}
+
+func TestCollectionNumericKeys(t *testing.T) {
+	c := Collect(arrString, WithKeyEqualer(NumericKeys{}))
+	assert.True(t, c.Has(int64(1), uint8(2), 3.0))
+	assert.Equal(t, "World", c.GetValue(int64(1)))
+	assert.True(t, c.Contains(float64(4), "Ready"))
+
+	set := c.Set(int64(1), "There")
+	assert.Equal(t, 5, set.Size())
+	assert.Equal(t, []interface{}{0, 1, 2, 3, 4}, set.Keys().All())
+	assert.Equal(t, "There", set.GetValue(1))
+	assert.Equal(t, "World", c.GetValue(1))
+
+	assert.Equal(t, []interface{}{1, 3}, c.Only(int64(1), 3.0).Keys().All())
+	assert.Equal(t, []interface{}{0, 2, 4}, c.Except(int64(1), 3.0).Keys().All())
+	assert.Equal(t, []interface{}{0, 2, 3, 4}, c.Unset(uint(1)).Keys().All())
+	assert.Equal(t, []interface{}{0, 1, 2, 3, 4, int64(5)}, c.Append(int64(5), "!").Keys().All())
+	assert.PanicsWithValue(t, "the new key is already exists", func() { c.Append(int64(4), "!") })
+
+	merged := Collect(map[interface{}]string{1: "int", int64(1): "int64", 2: "two"}, WithKeyEqualer(NumericKeys{}))
+	assert.Equal(t, 2, merged.Size())
+	assert.Contains(t, []string{"int", "int64"}, merged.GetValue(1))
+	assert.Equal(t, "two", merged.GetValue(2.0))
+}
+
+func TestCollectionNumericKeysLarge(t *testing.T) {
+	const size = 50000
+	items := map[interface{}]int{}
+	keys := make([]interface{}, size)
+	for i := 0; i < size; i++ {
+		items[i] = i
+		keys[i] = float64(i)
+	}
+	items[int64(0)] = -1
+
+	c := Collect(items, WithKeyEqualer(NumericKeys{}))
+	assert.Equal(t, size, c.Size())
+	assert.Contains(t, []interface{}{0, -1}, c.GetValue(0.0))
+	assert.True(t, c.Has(keys...))
+	assert.False(t, c.Has(append(keys, size)...))
+}
+
+func TestCollectionDeepKeys(t *testing.T) {
+	c := Collect(nil, WithKeyEqualer(DeepKeys{})).
+		Append([]string{"a", "b"}, 1).
+		Append(point{1, 2}, 2).
+		Append(map[string]int{"x": 1}, 3)
+
+	assert.NotPanics(t, func() { c.Has([]string{"a", "b"}) })
+	assert.True(t, c.Has([]string{"a", "b"}, point{1, 2}, map[string]int{"x": 1}))
+	assert.False(t, c.Has([]string{"a"}))
+	assert.Equal(t, 2, c.GetValue(point{1, 2}))
+	assert.Equal(t, 10, c.Set([]string{"a", "b"}, 10).GetValue([]string{"a", "b"}))
+	assert.Equal(t, 3, c.Set([]string{"a", "b"}, 10).Size())
+	assert.Equal(t, []interface{}{2}, c.Only(point{1, 2}).Values().All())
+	assert.Equal(t, []interface{}{1, 3}, c.Except(point{1, 2}).Values().All())
+	assert.Equal(t, []interface{}{1, 2}, c.Unset(map[string]int{"x": 1}).Values().All())
+	assert.PanicsWithValue(t, "the new key is already exists", func() { c.Append(point{1, 2}, 4) })
+}
+
+func TestCollectionKeyEqualerIsKept(t *testing.T) {
+	c := Collect(arrString, WithKeyEqualer(NumericKeys{}))
+	filtered := c.Filter(func(value interface{}, key interface{}, index int) bool { return index > 0 })
+	assert.Equal(t, "World", filtered.GetValue(1.0))
+	assert.Equal(t, "World", c.Chunk(2).GetValue(0).(Collection).GetValue(int64(1)))
+	assert.Equal(t, "World", c.Map(func(value interface{}, key interface{}, index int) (interface{}, interface{}) { return value, key }).GetValue(int8(1)))
+}
+
+func TestCollectFold(t *testing.T) {
+	headers := CollectFold(map[string]string{"Content-Type": "text/html", "X-Request-Id": "42"})
+	assert.Equal(t, "text/html", headers.GetValue("content-type"))
+	assert.Equal(t, "42", headers.GetValue("x-request-id"))
+	assert.True(t, headers.Has("CONTENT-TYPE"))
+	assert.True(t, headers.Contains("content-TYPE", "text/html"))
+
+	set := headers.Set("content-type", "application/json")
+	assert.Equal(t, 2, set.Size())
+	assert.Equal(t, []interface{}{"Content-Type", "X-Request-Id"}, set.Keys().All())
+	assert.Equal(t, "application/json", set.GetValue("Content-Type"))
+
+	assert.Equal(t, []interface{}{"X-Request-Id"}, headers.Except("content-type").Keys().All())
+	assert.Equal(t, []interface{}{"Content-Type"}, headers.Only("CONTENT-TYPE").Keys().All())
+	assert.Equal(t, []interface{}{"X-Request-Id"}, headers.Unset("content-type").Keys().All())
+	assert.PanicsWithValue(t, "the new key is already exists", func() { headers.Append("content-type", "text/plain") })
+
+	merged := CollectFold(map[string]int{"Accept": 1, "accept": 2, "ACCEPT": 3})
+	assert.Equal(t, 1, merged.Size())
+	assert.Equal(t, []interface{}{"ACCEPT"}, merged.Keys().All())
+	assert.Equal(t, 2, merged.GetValue("Accept"))
+
+	assert.True(t, CollectFold(map[string]int{"Kelvin": 1}).Has("\u212Aelvin"))
+}
+
+func TestCollectionWithKeyNormalizer(t *testing.T) {
+	env := Collect(map[string]string{"APP_DB_HOST": "localhost"}, WithKeyNormalizer(func(key string) string {
+		return strings.ToLower(strings.ReplaceAll(key, "-", "_"))
+	}))
+
+	assert.Equal(t, "localhost", env.GetValue("app-db-host"))
+	assert.Equal(t, []interface{}{"APP_DB_HOST"}, env.Set("app-db-host", "db").Keys().All())
+	assert.Equal(t, "db", env.Set("app-db-host", "db").GetValue("APP_DB_HOST"))
+}
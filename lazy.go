@@ -0,0 +1,79 @@
+package collection
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// LazyCollection represents a stream of items that are produced on demand
+type LazyCollection struct {
+	next func() (value interface{}, key interface{}, ok bool)
+	err  *error
+}
+
+// Lazy creates a LazyCollection from a generator, the generator returns false when the stream ends
+func Lazy(next func() (value interface{}, key interface{}, ok bool)) LazyCollection {
+	return LazyCollection{next: next, err: new(error)}
+}
+
+// Err gets the error that ended the stream early, it is nil when the stream ended normally
+func (l LazyCollection) Err() error {
+	if l.err == nil {
+		return nil
+	}
+	return *l.err
+}
+
+// Each looping each item until the stream ends
+func (l LazyCollection) Each(callback func(value interface{}, key interface{}, index int)) {
+	for index := 0; ; index++ {
+		value, key, ok := l.next()
+		if !ok {
+			return
+		}
+		callback(value, key, index)
+	}
+}
+
+// Collect reads the whole stream into a Collection
+func (l LazyCollection) Collect() Collection {
+	c := collect{}.build()
+	l.Each(func(value interface{}, key interface{}, index int) {
+		if err := c.check(key); err != nil {
+			panic(err.Error())
+		}
+		c.add(key, value)
+	})
+	return c.collect
+}
+
+// Sample gets n random items of the stream with reservoir sampling,
+// only the sampled items are kept in memory and they are returned in stream order
+func (l LazyCollection) Sample(source rand.Source, n int) Collection {
+	type item struct {
+		value, key interface{}
+		index      int
+	}
+
+	random := rand.New(source)
+	var reservoir []item
+	l.Each(func(value interface{}, key interface{}, index int) {
+		if index < n {
+			reservoir = append(reservoir, item{value, key, index})
+			return
+		}
+
+		if j := random.Intn(index + 1); j < n {
+			reservoir[j] = item{value, key, index}
+		}
+	})
+
+	sort.Slice(reservoir, func(i, j int) bool { return reservoir[i].index < reservoir[j].index })
+
+	c := collect{}
+	for _, item := range reservoir {
+		c.keys = append(c.keys, item.key)
+		c.values = append(c.values, item.value)
+	}
+	return c
+}
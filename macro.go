@@ -0,0 +1,67 @@
+package collection
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Macro is a user-defined collection method that can be called on any collection with Call
+type Macro func(collection Collection, args ...interface{}) Collection
+
+// ErrMacroNotRegistered is returned when calling a macro that is not registered
+var ErrMacroNotRegistered = errors.New("collection: macro is not registered")
+
+var macros = struct {
+	sync.RWMutex
+	registry map[string]Macro
+}{registry: map[string]Macro{}}
+
+// Register adds a macro under the name, registering the same name again replaces the macro.
+// It is safe to register macros from several goroutines.
+func Register(name string, macro Macro) {
+	if name == "" {
+		panic("the macro name must not be empty")
+	}
+
+	if macro == nil {
+		panic("the macro must not be nil")
+	}
+
+	macros.Lock()
+	defer macros.Unlock()
+	macros.registry[name] = macro
+}
+
+// HasMacro is a macro registered under the name
+func HasMacro(name string) bool {
+	_, ok := lookupMacro(name)
+	return ok
+}
+
+func lookupMacro(name string) (Macro, bool) {
+	macros.RLock()
+	defer macros.RUnlock()
+	macro, ok := macros.registry[name]
+	return macro, ok
+}
+
+// Call runs the registered macro with the collection and arguments,
+// it panics with an error wrapping ErrMacroNotRegistered when the macro is not registered
+func (c collect) Call(name string, args ...interface{}) Collection {
+	result, err := c.TryCall(name, args...)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// TryCall runs the registered macro with the collection and arguments,
+// it returns an error wrapping ErrMacroNotRegistered when the macro is not registered
+func (c collect) TryCall(name string, args ...interface{}) (Collection, error) {
+	macro, ok := lookupMacro(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrMacroNotRegistered, name)
+	}
+	return macro(c, args...), nil
+}
@@ -0,0 +1,63 @@
+package collection
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func init() {
+	Register("trimStrings", func(c Collection, args ...interface{}) Collection {
+		return c.Map(func(value interface{}, key interface{}, index int) (interface{}, interface{}) {
+			if s, ok := value.(string); ok {
+				return strings.TrimSpace(s), key
+			}
+			return value, key
+		})
+	})
+
+	Register("prefix", func(c Collection, args ...interface{}) Collection {
+		return c.Map(func(value interface{}, key interface{}, index int) (interface{}, interface{}) {
+			return fmt.Sprintf("%v%v", args[0], value), key
+		})
+	})
+}
+
+func TestCollectionCall(t *testing.T) {
+	c := Collect([]interface{}{" Hello ", "World  ", 3}).Call("trimStrings").Call("prefix", "> ")
+	assert.Equal(t, []interface{}{"> Hello", "> World", "> 3"}, c.Values().All())
+	assert.True(t, HasMacro("prefix"))
+	assert.False(t, HasMacro("slugs"))
+}
+
+func TestCollectionCallUnknownMacro(t *testing.T) {
+	_, err := Collect(arrString).TryCall("slugs")
+	assert.True(t, errors.Is(err, ErrMacroNotRegistered))
+	assert.EqualError(t, err, `collection: macro is not registered: "slugs"`)
+
+	assert.PanicsWithError(t, `collection: macro is not registered: "slugs"`, func() { Collect(arrString).Call("slugs") })
+}
+
+func TestRegisterConcurrently(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		name := fmt.Sprintf("concurrent%d", i%5)
+		go func() {
+			defer wg.Done()
+			Register(name, func(c Collection, args ...interface{}) Collection { return c })
+		}()
+		go func() {
+			defer wg.Done()
+			Collect(arrString).TryCall(name)
+		}()
+	}
+	wg.Wait()
+
+	assert.True(t, HasMacro("concurrent4"))
+	assert.PanicsWithValue(t, "the macro name must not be empty", func() { Register("", func(c Collection, args ...interface{}) Collection { return c }) })
+	assert.PanicsWithValue(t, "the macro must not be nil", func() { Register("nil", nil) })
+}
@@ -0,0 +1,168 @@
+package collection
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ConflictPolicy resolves a key present in both collections of a merge,
+// it gets the key with the existing and the new value and returns the merged value
+type ConflictPolicy func(key interface{}, left interface{}, right interface{}) (interface{}, error)
+
+// ErrConflict is returned by ErrorOnConflict
+var ErrConflict = errors.New("conflicting values")
+
+// KeepLeft keeps the existing value
+func KeepLeft(key interface{}, left interface{}, right interface{}) (interface{}, error) {
+	return left, nil
+}
+
+// KeepRight keeps the new value
+func KeepRight(key interface{}, left interface{}, right interface{}) (interface{}, error) {
+	return right, nil
+}
+
+// ErrorOnConflict fails the merge with ErrConflict
+func ErrorOnConflict(key interface{}, left interface{}, right interface{}) (interface{}, error) {
+	return nil, ErrConflict
+}
+
+// ConflictError reports the key whose conflict could not be resolved
+type ConflictError struct {
+	// Path is the keys leading to the conflicting entry through nested collections
+	Path []interface{}
+
+	// Err is the error returned by the conflict policy
+	Err error
+}
+
+// Error describes the conflicting key with its dot path
+func (e *ConflictError) Error() string {
+	path := ""
+	for _, key := range e.Path {
+		path = joinPath(path, fmt.Sprint(key))
+	}
+	return fmt.Sprintf("collection: cannot merge key %q: %v", path, e.Err)
+}
+
+// Unwrap gets the error returned by the conflict policy
+func (e *ConflictError) Unwrap() error {
+	return e.Err
+}
+
+// Merge merges the other collections into the collection, the later values win on conflicting keys.
+// Integer keys never conflict, their values are appended and all integer keys are renumbered
+// from zero, like PHP array_merge. When the keys mix kinds, such as string and integer keys,
+// the result compares its keys with DeepKeys so it can hold all of them.
+func (c collect) Merge(others ...Collection) Collection {
+	merged, _ := c.MergeWith(KeepRight, others...)
+	return merged
+}
+
+// MergeWith merges the other collections into the collection, resolving conflicting keys with the policy.
+// Integer keys are renumbered like Merge. It fails with a *ConflictError when the policy fails.
+func (c collect) MergeWith(policy ConflictPolicy, others ...Collection) (Collection, error) {
+	return merge(c, others, policy, false, true, nil)
+}
+
+// MergeRecursive is like Merge, but conflicting nested collections, maps, and slices are merged together
+// instead of being replaced, the result holds them as collections
+func (c collect) MergeRecursive(others ...Collection) Collection {
+	merged, _ := c.MergeRecursiveWith(KeepRight, others...)
+	return merged
+}
+
+// MergeRecursiveWith is like MergeWith, but conflicting nested collections, maps, and slices are merged together
+// with the same policy, the policy only resolves the conflicts between other values
+func (c collect) MergeRecursiveWith(policy ConflictPolicy, others ...Collection) (Collection, error) {
+	return merge(c, others, policy, true, true, nil)
+}
+
+// Replace replaces the values with the values of the other collections under the same keys,
+// new keys are added to the end. Unlike Merge, integer keys are kept like PHP array_replace.
+func (c collect) Replace(others ...Collection) Collection {
+	replaced, _ := merge(c, others, KeepRight, false, false, nil)
+	return replaced
+}
+
+// ReplaceRecursive is like Replace, but nested collections, maps, and slices under the same key
+// are replaced recursively, the result holds them as collections
+func (c collect) ReplaceRecursive(others ...Collection) Collection {
+	replaced, _ := merge(c, others, KeepRight, true, false, nil)
+	return replaced
+}
+
+// merge merges the collections in order. A collection without a key equaler gets DeepKeys
+// when the merged keys mix kinds, so it can hold integer and string keys like a PHP array.
+func merge(base Collection, others []Collection, policy ConflictPolicy, recursive bool, reindex bool, path []interface{}) (Collection, error) {
+	merged := collect{}.build()
+	if c, ok := base.(collect); ok {
+		merged = c.with(nil, nil).build()
+	}
+
+	next := 0
+	for _, source := range append([]Collection{base}, others...) {
+		values := source.Values()
+		for i, key := range source.Keys() {
+			if reindex && isIntKey(key) {
+				key, next = next, next+1
+			} else if index := merged.indexOf(key); index > -1 {
+				value, err := mergeValues(merged.keys[index], merged.values[index], values[i], policy, recursive, reindex, childPath(path, key))
+				if err != nil {
+					return nil, err
+				}
+				merged.values[index] = value
+				continue
+			}
+
+			if merged.checkKeyType(key) == errKeyType {
+				merged.equaler = DeepKeys{}
+			}
+
+			if err := merged.check(key); err != nil {
+				panic(err.Error())
+			}
+			merged.add(key, values[i])
+		}
+	}
+	return merged.collect, nil
+}
+
+// mergeValues gets the merged value of a conflicting key
+func mergeValues(key interface{}, left interface{}, right interface{}, policy ConflictPolicy, recursive bool, reindex bool, path []interface{}) (interface{}, error) {
+	if recursive {
+		leftNested, leftOk := nested(left)
+		rightNested, rightOk := nested(right)
+		if leftOk && rightOk {
+			return merge(leftNested, []Collection{rightNested}, policy, recursive, reindex, path)
+		}
+	}
+
+	value, err := policy(key, left, right)
+	if err != nil {
+		return nil, &ConflictError{Path: path, Err: err}
+	}
+	return value, nil
+}
+
+// nested gets a collection, map, slice, or array as a collection
+func nested(value interface{}) (Collection, bool) {
+	if c, ok := value.(Collection); ok {
+		return c, true
+	}
+
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array:
+		return Collect(value), true
+	}
+	return nil, false
+}
+
+func isIntKey(key interface{}) bool {
+	switch reflect.ValueOf(key).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
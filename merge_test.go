@@ -0,0 +1,89 @@
+package collection
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCollectionMerge(t *testing.T) {
+	left := Collect(map[string]interface{}{"a": 1, "b": 2})
+	right := Collect(map[string]interface{}{"b": 3, "c": 4})
+
+	merged := left.Merge(right)
+	assert.Equal(t, []interface{}{"a", "b", "c"}, merged.Keys().All())
+	assert.Equal(t, []interface{}{1, 3, 4}, merged.Values().All())
+
+	indexed := Collect([]string{"a", "b"}).Unset(0).Merge(Collect([]string{"c"}), Collect([]string{"d", "e"}))
+	assert.Equal(t, []interface{}{0, 1, 2, 3}, indexed.Keys().All())
+	assert.Equal(t, []interface{}{"b", "c", "d", "e"}, indexed.Values().All())
+
+	assert.Equal(t, 2, left.Merge().Size())
+
+	mixed := Collect(map[string]int{"a": 1}).Merge(Collect([]int{5, 6}), Collect(map[string]int{"a": 2}))
+	assert.Equal(t, []interface{}{"a", 0, 1}, mixed.Keys().All())
+	assert.Equal(t, []interface{}{2, 5, 6}, mixed.Values().All())
+	assert.Equal(t, 5, mixed.GetValue(0))
+	assert.Equal(t, 4, mixed.Set(int64(0), 7).Size())
+
+	replaced := Collect(map[string]int{"a": 1}).Replace(Collect(map[int]string{3: "x"}))
+	assert.Equal(t, []interface{}{"a", 3}, replaced.Keys().All())
+}
+
+func TestCollectionMergeWith(t *testing.T) {
+	left := Collect(map[string]interface{}{"a": 1, "b": 2})
+	right := Collect(map[string]interface{}{"b": 3, "c": 4})
+
+	kept, err := left.MergeWith(KeepLeft, right)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 2, 4}, kept.Values().All())
+
+	summed, err := left.MergeWith(func(key interface{}, left interface{}, right interface{}) (interface{}, error) {
+		return left.(int) + right.(int), nil
+	}, right, right)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 8, 8}, summed.Values().All())
+
+	_, err = left.MergeWith(ErrorOnConflict, right)
+	assert.True(t, errors.Is(err, ErrConflict))
+	assert.EqualError(t, err, `collection: cannot merge key "b": conflicting values`)
+}
+
+func TestCollectionMergeRecursive(t *testing.T) {
+	left := Collect(map[string]interface{}{
+		"name": "app",
+		"db":   map[string]interface{}{"host": "localhost", "port": 5432},
+		"tags": []string{"a"},
+	})
+	right := Collect(map[string]interface{}{
+		"db":   Collect(map[string]interface{}{"port": 6432, "user": "root"}),
+		"tags": Collect([]string{"b"}),
+	})
+
+	merged := left.MergeRecursive(right)
+	db := merged.GetValue("db").(Collection)
+	assert.Equal(t, []interface{}{"host", "port", "user"}, db.Keys().All())
+	assert.Equal(t, []interface{}{"localhost", 6432, "root"}, db.Values().All())
+	assert.Equal(t, []interface{}{"a", "b"}, merged.GetValue("tags").(Collection).Values().All())
+
+	_, err := left.MergeRecursiveWith(ErrorOnConflict, right)
+	var conflict *ConflictError
+	assert.True(t, errors.As(err, &conflict))
+	assert.Equal(t, []interface{}{"db", "port"}, conflict.Path)
+	assert.EqualError(t, err, `collection: cannot merge key "db.port": conflicting values`)
+
+	scalars := left.MergeRecursive(Collect(map[string]interface{}{"db": "sqlite"}))
+	assert.Equal(t, "sqlite", scalars.GetValue("db"))
+}
+
+func TestCollectionReplace(t *testing.T) {
+	replaced := Collect(arrString).Replace(Collect(map[int]string{1: "There", 5: "Go"}))
+	assert.Equal(t, []interface{}{0, 1, 2, 3, 4, 5}, replaced.Keys().All())
+	assert.Equal(t, []interface{}{"Hello", "There", "Are", "You", "Ready", "Go"}, replaced.Values().All())
+
+	nestedLeft := Collect(map[string]interface{}{"list": []int{1, 2, 3}})
+	nestedRight := Collect(map[string]interface{}{"list": map[int]int{1: 20}})
+
+	assert.Equal(t, map[int]int{1: 20}, nestedLeft.Replace(nestedRight).GetValue("list"))
+	assert.Equal(t, []interface{}{1, 20, 3}, nestedLeft.ReplaceRecursive(nestedRight).GetValue("list").(Collection).Values().All())
+}
@@ -0,0 +1,82 @@
+package collection
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// child gets the value under the key inside a collection, map, slice, or array,
+// keys are matched by their formatted value so "1" finds the key 1
+func child(value interface{}, key string) (interface{}, bool) {
+	if c, ok := value.(Collection); ok {
+		for i, k := range c.Keys() {
+			if fmt.Sprint(k) == key {
+				return c.Values().Get(i), true
+			}
+		}
+		return nil, false
+	}
+
+	val := reflect.ValueOf(value)
+	switch val.Kind() {
+	case reflect.Map:
+		iter := val.MapRange()
+		for iter.Next() {
+			if fmt.Sprint(iter.Key().Interface()) == key {
+				return iter.Value().Interface(), true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if i, err := strconv.Atoi(key); err == nil && i >= 0 && i < val.Len() {
+			return val.Index(i).Interface(), true
+		}
+	}
+
+	return nil, false
+}
+
+// children gets the formatted keys of a collection, map, slice, or array in their collection order
+func children(value interface{}) ([]string, bool) {
+	var keys []string
+	if c, ok := value.(Collection); ok {
+		for _, k := range c.Keys() {
+			keys = append(keys, fmt.Sprint(k))
+		}
+		return keys, true
+	}
+
+	val := reflect.ValueOf(value)
+	switch val.Kind() {
+	case reflect.Map:
+		for _, k := range Collect(value).Keys() {
+			keys = append(keys, fmt.Sprint(k))
+		}
+		return keys, true
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			keys = append(keys, strconv.Itoa(i))
+		}
+		return keys, true
+	}
+
+	return nil, false
+}
+
+// Lookup gets the value at the dot path inside a collection, map, slice, or array, like "address.city".
+// Keys are matched by their formatted value, and an empty path gets the value itself.
+func Lookup(value interface{}, path string) (interface{}, bool) {
+	if path == "" {
+		return value, true
+	}
+
+	for _, key := range strings.Split(path, ".") {
+		next, ok := child(value, key)
+		if !ok {
+			return nil, false
+		}
+		value = next
+	}
+	return value, true
+}
@@ -0,0 +1,70 @@
+package collection
+
+// Pipe passes the collection to the callback and returns the result
+func (c collect) Pipe(callback func(collection Collection) Collection) Collection {
+	return callback(c)
+}
+
+// Stage is a reusable transformation of a collection
+type Stage func(collection Collection) Collection
+
+// StageStats reports the number of items that went in and out of a pipeline stage
+type StageStats struct {
+	Name    string
+	In      int
+	Out     int
+	Skipped bool
+}
+
+// Pipeline composes stages that can be run on many collections.
+// Adding a stage returns a new pipeline, so a pipeline can be shared and extended safely.
+type Pipeline struct {
+	stages []pipelineStage
+}
+
+type pipelineStage struct {
+	name     string
+	criteria func(collection Collection) bool
+	stage    Stage
+}
+
+// NewPipeline creates a pipeline without stages
+func NewPipeline() Pipeline {
+	return Pipeline{}
+}
+
+// Then adds a stage to the end of the pipeline
+func (p Pipeline) Then(name string, stage Stage) Pipeline {
+	return p.When(name, func(collection Collection) bool { return true }, stage)
+}
+
+// When adds a stage that only runs when the collection coming into it meets the criteria
+func (p Pipeline) When(name string, criteria func(collection Collection) bool, stage Stage) Pipeline {
+	stages := append([]pipelineStage{}, p.stages...)
+	return Pipeline{stages: append(stages, pipelineStage{name: name, criteria: criteria, stage: stage})}
+}
+
+// Run passes the collection through every stage and returns the result
+func (p Pipeline) Run(collection Collection) Collection {
+	result, _ := p.Trace(collection)
+	return result
+}
+
+// Trace passes the collection through every stage and reports the items that went in and out of each stage
+func (p Pipeline) Trace(collection Collection) (Collection, []StageStats) {
+	stats := make([]StageStats, len(p.stages))
+	for i, s := range p.stages {
+		stat := &stats[i]
+		stat.Name, stat.Skipped = s.name, true
+		stage := s.stage
+
+		collection = collection.
+			Tap(func(in Collection) { stat.In = in.Size() }).
+			When(s.criteria, func(in Collection) Collection {
+				stat.Skipped = false
+				return stage(in)
+			}).
+			Tap(func(out Collection) { stat.Out = out.Size() })
+	}
+	return collection, stats
+}
@@ -0,0 +1,50 @@
+package collection
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestCollectionPipe(t *testing.T) {
+	c := Collect(arrString).Pipe(func(c Collection) Collection {
+		return c.Only(0, 1)
+	})
+
+	assert.Equal(t, []interface{}{"Hello", "World"}, c.Values().All())
+}
+
+func TestPipeline(t *testing.T) {
+	long := NewPipeline().
+		Then("long words", func(c Collection) Collection {
+			return c.Filter(func(value interface{}, key interface{}, index int) bool { return len(value.(string)) > 3 })
+		}).
+		Then("upper", func(c Collection) Collection {
+			return c.Map(func(value interface{}, key interface{}, index int) (interface{}, interface{}) {
+				return strings.ToUpper(value.(string)), key
+			})
+		})
+
+	first := long.When("first two", func(c Collection) bool { return c.Size() > 2 }, func(c Collection) Collection {
+		return c.Only(c.Keys().Get(0), c.Keys().Get(1))
+	})
+
+	assert.Equal(t, []interface{}{"HELLO", "WORLD", "READY"}, long.Run(Collect(arrString)).Values().All())
+	assert.Equal(t, []interface{}{"HELLO", "WORLD"}, first.Run(Collect(arrString)).Values().All())
+	assert.Equal(t, []interface{}{"JOHN"}, first.Run(Collect(arrMap).Except("Age")).Values().All())
+
+	result, stats := first.Trace(Collect(arrString))
+	assert.Equal(t, 2, result.Size())
+	assert.Equal(t, []StageStats{
+		{Name: "long words", In: 5, Out: 3},
+		{Name: "upper", In: 3, Out: 3},
+		{Name: "first two", In: 3, Out: 2},
+	}, stats)
+
+	_, stats = first.Trace(Collect([]string{"Hi", "Hello"}))
+	assert.Equal(t, StageStats{Name: "first two", In: 1, Out: 1, Skipped: true}, stats[2])
+
+	assert.Equal(t, 2, len(long.stages))
+	assert.True(t, Collect(arrString).Equals(NewPipeline().Run(Collect(arrString))))
+	assert.Equal(t, []interface{}{"HELLO", "WORLD"}, Collect(arrString).Pipe(first.Run).Values().All())
+}
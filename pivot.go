@@ -0,0 +1,149 @@
+package collection
+
+import (
+	"github.com/habibimustafa/collection/arr"
+	"github.com/habibimustafa/collection/deep"
+	"github.com/habibimustafa/collection/sort"
+	gosort "sort"
+)
+
+// Pivot reshapes a collection of rows from long to wide format.
+//
+// Each row is a collection or a map. The values under rowKey become the keys of the result,
+// in order of first appearance, and the values under columnKey become the keys of each
+// result row, sorted with sort.Compare. The values under valueKey that share the same row
+// and column are combined with the aggregate callback, the last one is kept when it is nil.
+// Cells without values are filled with the fill value, or nil when it is not provided.
+// Rows missing the row or column key, or holding nil under it, are skipped. Numbers of different types with
+// the same value are the same row or column.
+func (c collect) Pivot(rowKey string, columnKey string, valueKey string, aggregate func(values arr.Array) interface{}, fill ...interface{}) Collection {
+	if len(fill) > 1 {
+		panic("only one fill value is allowed")
+	}
+
+	var empty interface{}
+	if len(fill) == 1 {
+		empty = fill[0]
+	}
+
+	if aggregate == nil {
+		aggregate = func(values arr.Array) interface{} { return values.Last() }
+	}
+
+	rowIndex, columnIndex := newPivotIndex(), newPivotIndex()
+	cells := map[[2]int]arr.Array{}
+	for _, item := range c.values {
+		row, rowOk := child(item, rowKey)
+		column, columnOk := child(item, columnKey)
+		if !rowOk || !columnOk || row == nil || column == nil {
+			continue
+		}
+
+		value, _ := child(item, valueKey)
+		cell := [2]int{rowIndex.indexOf(row), columnIndex.indexOf(column)}
+		cells[cell] = append(cells[cell], value)
+	}
+
+	rows, columns := rowIndex.values, columnIndex.values
+
+	order := make([]int, len(columns))
+	for i := range order {
+		order[i] = i
+	}
+	gosort.SliceStable(order, func(i, j int) bool {
+		return sort.Compare(columns[order[i]], columns[order[j]]) < 0
+	})
+
+	pivot := collect{equaler: NumericKeys{}}
+	for i, row := range rows {
+		wide := collect{equaler: NumericKeys{}}
+		for _, j := range order {
+			value := empty
+			if values, ok := cells[[2]int{i, j}]; ok {
+				value = aggregate(values)
+			}
+
+			wide.keys = append(wide.keys, columns[j])
+			wide.values = append(wide.values, value)
+		}
+
+		pivot.keys = append(pivot.keys, row)
+		pivot.values = append(pivot.values, wide)
+	}
+	return pivot
+}
+
+// pivotIndex keeps distinct values in order of first appearance, numbers of different types
+// with the same value are the same. Values are found by their hash.
+type pivotIndex struct {
+	values  []interface{}
+	buckets map[uint64][]int
+}
+
+func newPivotIndex() *pivotIndex {
+	return &pivotIndex{buckets: map[uint64][]int{}}
+}
+
+// indexOf gets the index of the value, the value is added when it is not found
+func (p *pivotIndex) indexOf(value interface{}) int {
+	hash := deep.HashNumeric(value)
+	for _, i := range p.buckets[hash] {
+		if deep.EqualNumeric(p.values[i], value) {
+			return i
+		}
+	}
+
+	p.values = append(p.values, value)
+	p.buckets[hash] = append(p.buckets[hash], len(p.values)-1)
+	return len(p.values) - 1
+}
+
+// Unpivot reshapes a collection of rows from wide to long format.
+//
+// Every value column of every row becomes its own row holding the id keys of the original row,
+// followed by "variable" with the column name and "value" with the column value. All the keys
+// except the id keys are value columns when no value columns are provided. Missing id keys
+// and value columns are left out. The result keys are the positions of the new rows.
+// It panics when the id keys repeat or are named "variable" or "value".
+func (c collect) Unpivot(idKeys []string, valueColumns []string) Collection {
+	for i, id := range idKeys {
+		if id == "variable" || id == "value" {
+			panic(`the id keys must not be named "variable" or "value"`)
+		}
+
+		if arr.List(idKeys[:i]).Has(id) {
+			panic("the id keys must not repeat")
+		}
+	}
+
+	long := collect{}
+	for _, item := range c.values {
+		columns := valueColumns
+		if len(columns) == 0 {
+			keys, _ := children(item)
+			for _, key := range keys {
+				if !arr.List(idKeys).Has(key) {
+					columns = append(columns, key)
+				}
+			}
+		}
+
+		ids := collect{}
+		for _, id := range idKeys {
+			if value, ok := child(item, id); ok {
+				ids = ids.Append(id, value).(collect)
+			}
+		}
+
+		for _, column := range columns {
+			value, ok := child(item, column)
+			if !ok {
+				continue
+			}
+
+			row := ids.Append("variable", column).Append("value", value)
+			long = long.push(row)
+		}
+	}
+	return long
+}
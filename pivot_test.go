@@ -0,0 +1,104 @@
+package collection
+
+import (
+	"github.com/habibimustafa/collection/arr"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var sales = []map[string]interface{}{
+	{"region": "East", "quarter": "Q2", "amount": 20},
+	{"region": "West", "quarter": "Q1", "amount": 15},
+	{"region": "East", "quarter": "Q1", "amount": 10},
+	{"region": "East", "quarter": "Q1", "amount": 5},
+	{"region": "West", "amount": 99},
+}
+
+func sum(values arr.Array) interface{} {
+	total := 0
+	for _, value := range values {
+		total += value.(int)
+	}
+	return total
+}
+
+func TestCollectionPivot(t *testing.T) {
+	p := Collect(sales).Pivot("region", "quarter", "amount", sum, 0)
+
+	assert.Equal(t, []interface{}{"East", "West"}, p.Keys().All())
+
+	east := p.GetValue("East").(Collection)
+	assert.Equal(t, []interface{}{"Q1", "Q2"}, east.Keys().All())
+	assert.Equal(t, []interface{}{15, 20}, east.Values().All())
+
+	west := p.GetValue("West").(Collection)
+	assert.Equal(t, []interface{}{15, 0}, west.Values().All())
+
+	last := Collect(sales).Pivot("region", "quarter", "amount", nil)
+	assert.Equal(t, 5, last.GetValue("East").(Collection).GetValue("Q1"))
+	assert.Nil(t, last.GetValue("West").(Collection).GetValue("Q2"))
+
+	withNil := Collect([]map[string]interface{}{
+		{"region": "East", "quarter": nil, "amount": 1},
+		{"region": nil, "quarter": "Q1", "amount": 2},
+		{"region": "East", "quarter": "Q1", "amount": 3},
+	}).Pivot("region", "quarter", "amount", sum)
+	assert.Equal(t, []interface{}{"East"}, withNil.Keys().All())
+	assert.Equal(t, []interface{}{"Q1"}, withNil.GetValue("East").(Collection).Keys().All())
+
+	assert.PanicsWithValue(t, "only one fill value is allowed", func() {
+		Collect(sales).Pivot("region", "quarter", "amount", sum, 0, 1)
+	})
+}
+
+func TestCollectionPivotNumericColumns(t *testing.T) {
+	rows := []map[string]interface{}{
+		{"id": 1, "year": 2021, "score": 3},
+		{"id": 1, "year": 2020.0, "score": 2},
+		{"id": 1.0, "year": int64(2021), "score": 4},
+	}
+
+	p := Collect(rows).Pivot("id", "year", "score", sum)
+	assert.Equal(t, 1, p.Size())
+
+	scores := p.GetValue(1).(Collection)
+	assert.Equal(t, []interface{}{2020.0, 2021}, scores.Keys().All())
+	assert.Equal(t, []interface{}{2, 7}, scores.Values().All())
+}
+
+func TestCollectionUnpivot(t *testing.T) {
+	rows := []map[string]interface{}{
+		{"name": "Ann", "math": 90, "art": 80},
+		{"name": "Bob", "math": 70},
+	}
+
+	long := Collect(rows).Unpivot([]string{"name"}, []string{"math", "art"})
+	assert.Equal(t, []interface{}{0, 1, 2}, long.Keys().All())
+
+	first := long.GetValue(0).(Collection)
+	assert.Equal(t, []interface{}{"name", "variable", "value"}, first.Keys().All())
+	assert.Equal(t, []interface{}{"Ann", "math", 90}, first.Values().All())
+	assert.Equal(t, []interface{}{"Ann", "art", 80}, long.GetValue(1).(Collection).Values().All())
+	assert.Equal(t, []interface{}{"Bob", "math", 70}, long.GetValue(2).(Collection).Values().All())
+
+	all := Collect(rows).Unpivot([]string{"name"}, nil)
+	assert.Equal(t, []interface{}{"Ann", "art", 80}, all.GetValue(0).(Collection).Values().All())
+	assert.Equal(t, 3, all.Size())
+
+	reserved := []map[string]interface{}{{"value": 1, "math": 90}}
+	assert.PanicsWithValue(t, `the id keys must not be named "variable" or "value"`, func() { Collect(reserved).Unpivot([]string{"value"}, nil) })
+	assert.PanicsWithValue(t, `the id keys must not be named "variable" or "value"`, func() { Collect(nil).Unpivot([]string{"variable"}, nil) })
+	assert.PanicsWithValue(t, "the id keys must not repeat", func() { Collect(rows).Unpivot([]string{"name", "name"}, nil) })
+}
+
+func TestCollectionPivotRoundTrip(t *testing.T) {
+	wide := Collect(sales).Pivot("region", "quarter", "amount", sum, 0)
+
+	rows := wide.Map(func(value interface{}, key interface{}, index int) (interface{}, interface{}) {
+		return value.(Collection).Prepend("region", key), index
+	})
+
+	long := rows.Unpivot([]string{"region"}, nil)
+	assert.Equal(t, 4, long.Size())
+	assert.Equal(t, []interface{}{"West", "Q2", 0}, long.GetValue(3).(Collection).Values().All())
+}
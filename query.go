@@ -0,0 +1,63 @@
+package collection
+
+// SortBy sorts the items by their callback results from the smallest, comparing them like sort.Compare.
+// Items with equal results keep their order, and the keys are kept.
+func (c collect) SortBy(callback func(value interface{}, key interface{}, index int) interface{}) Collection {
+	return c.rank(c.Size(), callback, false)
+}
+
+// SortByDesc sorts the items by their callback results from the largest, comparing them like sort.Compare.
+// Items with equal results keep their order, and the keys are kept.
+func (c collect) SortByDesc(callback func(value interface{}, key interface{}, index int) interface{}) Collection {
+	return c.rank(c.Size(), callback, true)
+}
+
+// GroupBy groups the items by their callback results, in order of first appearance.
+// Each group is a collection of its items with their original keys.
+// Numbers of different types with the same value are the same group.
+func (c collect) GroupBy(callback func(value interface{}, key interface{}, index int) interface{}) Collection {
+	index := newPivotIndex()
+	var members [][]int
+	for i := range c.keys {
+		group := index.indexOf(callback(c.values[i], c.keys[i], i))
+		if group == len(members) {
+			members = append(members, nil)
+		}
+		members[group] = append(members[group], i)
+	}
+
+	grouped := collect{equaler: NumericKeys{}}
+	for i, group := range index.values {
+		items := c.with(nil, nil)
+		for _, index := range members[i] {
+			items.keys = append(items.keys, c.keys[index])
+			items.values = append(items.values, c.values[index])
+		}
+
+		grouped.keys = append(grouped.keys, group)
+		grouped.values = append(grouped.values, items)
+	}
+	return grouped
+}
+
+// Pluck gets the values at the dot path inside each value, like "address.city".
+// Values without the path are left out, and the keys are kept.
+func (c collect) Pluck(path string) Collection {
+	plucked := c.with(nil, nil)
+	for i := range c.keys {
+		if value, ok := Lookup(c.values[i], path); ok {
+			plucked.keys = append(plucked.keys, c.keys[i])
+			plucked.values = append(plucked.values, value)
+		}
+	}
+	return plucked
+}
+
+// Sum adds up the numeric values, it panics when a value is not a number
+func (c collect) Sum() float64 {
+	var sum float64
+	for _, n := range c.numbers("the values must be numbers to be summed") {
+		sum += n
+	}
+	return sum
+}
@@ -0,0 +1,50 @@
+package collection
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var people = []map[string]interface{}{
+	{"name": "Cid", "age": 41, "address": map[string]string{"city": "Oslo"}},
+	{"name": "Ann", "age": 29.0, "address": map[string]string{"city": "Rome"}},
+	{"name": "Bob", "age": int64(29)},
+}
+
+func TestCollectionSortBy(t *testing.T) {
+	byAge := Collect(people).SortBy(byField("age"))
+	assert.Equal(t, []interface{}{1, 2, 0}, byAge.Keys().All())
+
+	byName := Collect(people).SortByDesc(byField("name"))
+	assert.Equal(t, []interface{}{0, 2, 1}, byName.Keys().All())
+}
+
+func TestCollectionGroupBy(t *testing.T) {
+	groups := Collect(people).GroupBy(byField("age"))
+
+	assert.Equal(t, []interface{}{41, 29.0}, groups.Keys().All())
+	assert.Equal(t, []interface{}{1, 2}, groups.GetValue(29).(Collection).Keys().All())
+	assert.Equal(t, 0, Collect(nil).GroupBy(byField("age")).Size())
+
+	values := make([]int, 40000)
+	for i := range values {
+		values[i] = i % 20000
+	}
+	distinct := Collect(values).GroupBy(func(value interface{}, key interface{}, index int) interface{} { return value })
+	assert.Equal(t, 20000, distinct.Size())
+	assert.Equal(t, []interface{}{5, 20005}, distinct.Values().Get(5).(Collection).Keys().All())
+}
+
+func TestCollectionPluck(t *testing.T) {
+	assert.Equal(t, []interface{}{"Cid", "Ann", "Bob"}, Collect(people).Pluck("name").Values().All())
+
+	cities := Collect(people).Pluck("address.city")
+	assert.Equal(t, []interface{}{0, 1}, cities.Keys().All())
+	assert.Equal(t, []interface{}{"Oslo", "Rome"}, cities.Values().All())
+}
+
+func TestCollectionSum(t *testing.T) {
+	assert.Equal(t, 99.0, Collect(people).Pluck("age").Sum())
+	assert.Equal(t, 0.0, Collect(nil).Sum())
+	assert.PanicsWithValue(t, "the values must be numbers to be summed", func() { Collect(arrString).Sum() })
+}
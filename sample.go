@@ -0,0 +1,61 @@
+package collection
+
+import (
+	"github.com/habibimustafa/collection/arr"
+	"math/rand"
+)
+
+// Shuffle gets the items in a random order decided by the source
+func (c collect) Shuffle(source rand.Source) Collection {
+	return c.pick(c.indexes().Shuffle(source))
+}
+
+// Random gets n random values keyed by their position, the same item can be picked more than once
+func (c collect) Random(source rand.Source, n int) Collection {
+	if n > 0 && c.Empty() {
+		panic("cannot get random items from empty collection")
+	}
+
+	var random collect
+	for _, index := range c.indexes().Random(source, n) {
+		random = random.push(c.values[index.(int)])
+	}
+	return random
+}
+
+// Sample gets n random items with their keys, each item can be picked only once, a size of zero or less gets no items
+func (c collect) Sample(source rand.Source, n int) Collection {
+	if n > c.Size() {
+		panic("the sample size is larger than the collection size")
+	}
+
+	return c.pick(c.indexes().Sample(source, n))
+}
+
+// WeightedSample gets n random items where items with larger weight are more likely to be picked,
+// each item can be picked only once and items with zero or negative weight are never picked.
+// A size of zero or less gets no items.
+func (c collect) WeightedSample(source rand.Source, n int, weight func(value interface{}, key interface{}, index int) float64) Collection {
+	if n > c.Size() {
+		panic("the sample size is larger than the collection size")
+	}
+
+	return c.pick(c.indexes().WeightedSample(source, n, func(item interface{}, index int) float64 {
+		return weight(c.values[index], c.keys[index], index)
+	}))
+}
+
+// indexes gets an array of the item positions
+func (c collect) indexes() arr.Array {
+	return arr.List(c.Values().Keys())
+}
+
+// pick gets the items at the given positions
+func (c collect) pick(indexes arr.Array) collect {
+	picked := c.with(nil, nil)
+	for _, index := range indexes {
+		picked.keys = append(picked.keys, c.keys[index.(int)])
+		picked.values = append(picked.values, c.values[index.(int)])
+	}
+	return picked
+}
@@ -0,0 +1,94 @@
+package collection
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestCollectionShuffle(t *testing.T) {
+	c := Collect(arrString)
+	shuffled := c.Shuffle(rand.NewSource(42))
+
+	assert.True(t, shuffled.Equals(c.Shuffle(rand.NewSource(42))))
+	assert.False(t, shuffled.Equals(c))
+	assert.True(t, shuffled.EqualsIgnoreOrder(c))
+	assert.Equal(t, []interface{}{"Hello", "World", "Are", "You", "Ready"}, c.Values().All())
+}
+
+func TestCollectionRandom(t *testing.T) {
+	c := Collect(arrMap)
+	random := c.Random(rand.NewSource(42), 5)
+
+	assert.Equal(t, []interface{}{0, 1, 2, 3, 4}, random.Keys().All())
+	assert.True(t, random.Equals(c.Random(rand.NewSource(42), 5)))
+	random.Each(func(value interface{}, key interface{}, index int) { assert.True(t, c.Values().Has(value)) })
+	assert.PanicsWithValue(t, "cannot get random items from empty collection", func() { Collect(nil).Random(rand.NewSource(42), 1) })
+}
+
+func TestCollectionSample(t *testing.T) {
+	c := Collect(arrString)
+	sample := c.Sample(rand.NewSource(42), 3)
+
+	assert.Equal(t, 3, sample.Size())
+	assert.True(t, sample.Equals(c.Sample(rand.NewSource(42), 3)))
+	sample.Each(func(value interface{}, key interface{}, index int) { assert.True(t, c.Contains(key, value)) })
+	assert.PanicsWithValue(t, "the sample size is larger than the collection size", func() { c.Sample(rand.NewSource(42), 6) })
+	assert.Equal(t, 0, c.Sample(rand.NewSource(42), 0).Size())
+	assert.Equal(t, 0, c.Sample(rand.NewSource(42), -1).Size())
+	assert.Equal(t, 0, Collect(nil).Sample(rand.NewSource(42), -1).Size())
+}
+
+func TestCollectionWeightedSample(t *testing.T) {
+	c := Collect(map[string]int{"never": 0, "rare": 1, "often": 1000})
+	weight := func(value interface{}, key interface{}, index int) float64 { return float64(value.(int)) }
+
+	sample := c.WeightedSample(rand.NewSource(42), 2, weight)
+	assert.True(t, sample.Equals(c.WeightedSample(rand.NewSource(42), 2, weight)))
+	assert.Equal(t, []interface{}{"often", "rare"}, sample.Keys().All())
+	assert.Equal(t, 2, c.WeightedSample(rand.NewSource(42), 3, weight).Size())
+	assert.Equal(t, 0, c.WeightedSample(rand.NewSource(42), -1, weight).Size())
+}
+
+func stream(n int) LazyCollection {
+	i := 0
+	return Lazy(func() (interface{}, interface{}, bool) {
+		if i >= n {
+			return nil, nil, false
+		}
+		i++
+		return i * 10, i - 1, true
+	})
+}
+
+func TestLazyCollection(t *testing.T) {
+	c := stream(3).Collect()
+	assert.Equal(t, []interface{}{0, 1, 2}, c.Keys().All())
+	assert.Equal(t, []interface{}{10, 20, 30}, c.Values().All())
+
+	repeated := Lazy(func() (interface{}, interface{}, bool) { return 1, "a", true })
+	assert.PanicsWithValue(t, "the new key is already exists", func() { repeated.Collect() })
+
+	count := 0
+	stream(5).Each(func(value interface{}, key interface{}, index int) {
+		assert.Equal(t, count, index)
+		count++
+	})
+	assert.Equal(t, 5, count)
+}
+
+func TestLazyCollectionSample(t *testing.T) {
+	assert.Equal(t, 0, stream(10).Sample(rand.NewSource(42), -1).Size())
+
+	sample := stream(1000).Sample(rand.NewSource(42), 5)
+	assert.Equal(t, 5, sample.Size())
+	assert.True(t, sample.Equals(stream(1000).Sample(rand.NewSource(42), 5)))
+	sample.Each(func(value interface{}, key interface{}, index int) {
+		assert.Equal(t, (key.(int)+1)*10, value)
+		if index > 0 {
+			assert.Greater(t, key, sample.Keys().Get(index-1))
+		}
+	})
+
+	assert.Equal(t, 3, stream(3).Sample(rand.NewSource(42), 5).Size())
+}
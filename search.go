@@ -0,0 +1,93 @@
+package collection
+
+import (
+	"github.com/habibimustafa/collection/arr"
+	"github.com/habibimustafa/collection/deep"
+)
+
+// ErrItemNotFound is returned by Sole when no item passes the callback
+var ErrItemNotFound = arr.ErrItemNotFound
+
+// ErrMultipleItemsFound is returned by Sole when more than one item passes the callback
+var ErrMultipleItemsFound = arr.ErrMultipleItemsFound
+
+// FirstWhere gets the first item that passes the callback, it is empty when no item passes
+func (c collect) FirstWhere(callback func(value interface{}, key interface{}, index int) bool) map[interface{}]interface{} {
+	index := c.searchIndex(callback)
+	if index < 0 {
+		return map[interface{}]interface{}{}
+	}
+	return c.Get(index)
+}
+
+// LastWhere gets the last item that passes the callback, it is empty when no item passes
+func (c collect) LastWhere(callback func(value interface{}, key interface{}, index int) bool) map[interface{}]interface{} {
+	for i := c.Size() - 1; i >= 0; i-- {
+		if callback(c.values[i], c.keys[i], i) {
+			return c.Get(i)
+		}
+	}
+	return map[interface{}]interface{}{}
+}
+
+// FirstOr gets the first value, or the default value when collection is empty
+func (c collect) FirstOr(defaultValue interface{}) interface{} {
+	return c.Values().FirstOr(defaultValue)
+}
+
+// Sole gets the only item that passes the callback,
+// it fails when no item or more than one item passes
+func (c collect) Sole(callback func(value interface{}, key interface{}, index int) bool) (map[interface{}]interface{}, error) {
+	matches := c.Filter(callback)
+	switch matches.Size() {
+	case 0:
+		return nil, ErrItemNotFound
+	case 1:
+		return matches.First(), nil
+	default:
+		return nil, ErrMultipleItemsFound
+	}
+}
+
+// Search gets the key of the first value deeply equal to the given value
+func (c collect) Search(value interface{}) (interface{}, bool) {
+	return c.SearchFunc(func(v interface{}, key interface{}, index int) bool {
+		return deep.Equal(v, value)
+	})
+}
+
+// SearchFunc gets the key of the first item that passes the callback
+func (c collect) SearchFunc(callback func(value interface{}, key interface{}, index int) bool) (interface{}, bool) {
+	index := c.searchIndex(callback)
+	if index < 0 {
+		return nil, false
+	}
+	return c.keys[index], true
+}
+
+// Every is every item passes the callback, it is true for an empty collection
+func (c collect) Every(callback func(value interface{}, key interface{}, index int) bool) bool {
+	return !c.Some(func(value interface{}, key interface{}, index int) bool {
+		return !callback(value, key, index)
+	})
+}
+
+// Some is at least one item passes the callback
+func (c collect) Some(callback func(value interface{}, key interface{}, index int) bool) bool {
+	return c.searchIndex(callback) > -1
+}
+
+// None is no item passes the callback
+func (c collect) None(callback func(value interface{}, key interface{}, index int) bool) bool {
+	return !c.Some(callback)
+}
+
+// searchIndex gets the index of the first item that passes the callback, or -1 when no item passes
+func (c collect) searchIndex(callback func(value interface{}, key interface{}, index int) bool) int {
+	for i := range c.keys {
+		if callback(c.values[i], c.keys[i], i) {
+			return i
+		}
+	}
+	return -1
+}
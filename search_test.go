@@ -0,0 +1,83 @@
+package collection
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func hasLetterO(value interface{}, key interface{}, index int) bool {
+	return strings.Contains(value.(string), "o")
+}
+
+func TestCollectionFirstAndLastOfEmpty(t *testing.T) {
+	assert.Empty(t, Collect(nil).First())
+	assert.Empty(t, Collect(nil).Last())
+}
+
+func TestCollectionFirstWhereAndLastWhere(t *testing.T) {
+	c := Collect(arrString)
+	assert.Equal(t, map[interface{}]interface{}{0: "Hello"}, c.FirstWhere(hasLetterO))
+	assert.Equal(t, map[interface{}]interface{}{3: "You"}, c.LastWhere(hasLetterO))
+
+	none := func(value interface{}, key interface{}, index int) bool { return false }
+	assert.Empty(t, c.FirstWhere(none))
+	assert.Empty(t, c.LastWhere(none))
+}
+
+func TestCollectionFirstOr(t *testing.T) {
+	assert.Equal(t, "Hello", Collect(arrString).FirstOr("Hi"))
+	assert.Equal(t, "Hi", Collect(nil).FirstOr("Hi"))
+}
+
+func TestCollectionSole(t *testing.T) {
+	c := Collect(arrMap)
+
+	sole, err := c.Sole(func(value interface{}, key interface{}, index int) bool { return value == "Doe" })
+	assert.NoError(t, err)
+	assert.Equal(t, map[interface{}]interface{}{"Last Name": "Doe"}, sole)
+
+	_, err = c.Sole(func(value interface{}, key interface{}, index int) bool { return value == "Jane" })
+	assert.Equal(t, ErrItemNotFound, err)
+
+	_, err = Collect(arrString).Sole(hasLetterO)
+	assert.Equal(t, ErrMultipleItemsFound, err)
+}
+
+func TestCollectionSearch(t *testing.T) {
+	c := Collect(map[string]interface{}{"a": []int{1, 2}, "b": "Hello", "c": "Hello"})
+
+	key, ok := c.Search("Hello")
+	assert.True(t, ok)
+	assert.Equal(t, "b", key)
+
+	key, ok = c.Search([]int{1, 2})
+	assert.True(t, ok)
+	assert.Equal(t, "a", key)
+
+	_, ok = c.Search("World")
+	assert.False(t, ok)
+
+	key, ok = Collect(arrString).SearchFunc(func(value interface{}, key interface{}, index int) bool {
+		return len(value.(string)) == 3
+	})
+	assert.True(t, ok)
+	assert.Equal(t, 2, key)
+}
+
+func TestCollectionEverySomeNone(t *testing.T) {
+	c := Collect(arrString)
+	capitalized := func(value interface{}, key interface{}, index int) bool {
+		return strings.ToUpper(value.(string)[:1]) == value.(string)[:1]
+	}
+
+	assert.True(t, c.Every(capitalized))
+	assert.False(t, c.Every(hasLetterO))
+	assert.True(t, Collect(nil).Every(hasLetterO))
+
+	assert.True(t, c.Some(hasLetterO))
+	assert.False(t, Collect(nil).Some(hasLetterO))
+
+	assert.True(t, Collect([]string{"Are"}).None(hasLetterO))
+	assert.False(t, c.None(hasLetterO))
+}
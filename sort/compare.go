@@ -0,0 +1,165 @@
+package sort
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Compare compares two values of any type. It returns -1, 0, 1
+// according to whether a < b (-1), a == b (0), or a > b (1).
+//
+// It follows the rules of Sort, with a few additions:
+//
+//   - numbers compare by value whatever their kind, so int(1), uint8(1)
+//     and float64(1) are equal
+//   - slices compare each element in turn, then by length
+//   - structs compare each field in turn with these rules, so collections
+//     compare by their keys and then by their values
+//   - funcs compare by machine address
+//   - values of different types compare by their type name, then by the
+//     package paths of the types
+//
+// Like Sort, it panics on maps.
+func Compare(a, b interface{}) int {
+	return compareValues(reflect.ValueOf(a), reflect.ValueOf(b))
+}
+
+func compareValues(aVal, bVal reflect.Value) int {
+	for aVal.IsValid() && aVal.Kind() == reflect.Interface {
+		aVal = aVal.Elem()
+	}
+	for bVal.IsValid() && bVal.Kind() == reflect.Interface {
+		bVal = bVal.Elem()
+	}
+
+	switch {
+	case !aVal.IsValid() && !bVal.IsValid():
+		return 0
+	case !aVal.IsValid():
+		return -1
+	case !bVal.IsValid():
+		return 1
+	}
+
+	if isNumber(aVal) && isNumber(bVal) {
+		return numberCompare(aVal, bVal)
+	}
+
+	if aType, bType := aVal.Type(), bVal.Type(); aType != bType {
+		if c := strings.Compare(aType.String(), bType.String()); c != 0 {
+			return c
+		}
+		return strings.Compare(typePath(aType), typePath(bType))
+	}
+
+	switch aVal.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < aVal.Len() && i < bVal.Len(); i++ {
+			if c := compareValues(aVal.Index(i), bVal.Index(i)); c != 0 {
+				return c
+			}
+		}
+		switch {
+		case aVal.Len() < bVal.Len():
+			return -1
+		case aVal.Len() > bVal.Len():
+			return 1
+		default:
+			return 0
+		}
+	case reflect.Struct:
+		for i := 0; i < aVal.NumField(); i++ {
+			if c := compareValues(aVal.Field(i), bVal.Field(i)); c != 0 {
+				return c
+			}
+		}
+		return 0
+	case reflect.Func:
+		if c, ok := nilCompare(aVal, bVal); ok {
+			return c
+		}
+		return compare(reflect.ValueOf(aVal.Pointer()), reflect.ValueOf(bVal.Pointer()))
+	default:
+		return compare(aVal, bVal)
+	}
+}
+
+// typePath names the type with the package paths of the named types it is made of,
+// so types whose names only differ by their package have different paths
+func typePath(t reflect.Type) string {
+	if t.Name() != "" {
+		return t.PkgPath() + "." + t.Name()
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return "*" + typePath(t.Elem())
+	case reflect.Slice:
+		return "[]" + typePath(t.Elem())
+	case reflect.Array:
+		return "[" + strconv.Itoa(t.Len()) + "]" + typePath(t.Elem())
+	case reflect.Map:
+		return "map[" + typePath(t.Key()) + "]" + typePath(t.Elem())
+	case reflect.Chan:
+		return "chan " + typePath(t.Elem())
+	}
+	return t.String()
+}
+
+func isNumber(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func isSigned(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+func isUnsigned(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+// numberCompare compares two numbers of any kind by value.
+// Integers are compared exactly, floats are compared with floatCompare.
+func numberCompare(aVal, bVal reflect.Value) int {
+	switch {
+	case isSigned(aVal) && isSigned(bVal):
+		return compare(reflect.ValueOf(aVal.Int()), reflect.ValueOf(bVal.Int()))
+	case isUnsigned(aVal) && isUnsigned(bVal):
+		return compare(reflect.ValueOf(aVal.Uint()), reflect.ValueOf(bVal.Uint()))
+	case isSigned(aVal) && isUnsigned(bVal):
+		if aVal.Int() < 0 {
+			return -1
+		}
+		return compare(reflect.ValueOf(uint64(aVal.Int())), reflect.ValueOf(bVal.Uint()))
+	case isUnsigned(aVal) && isSigned(bVal):
+		return -numberCompare(bVal, aVal)
+	default:
+		return floatCompare(toFloat(aVal), toFloat(bVal))
+	}
+}
+
+func toFloat(v reflect.Value) float64 {
+	switch {
+	case isSigned(v):
+		return float64(v.Int())
+	case isUnsigned(v):
+		return float64(v.Uint())
+	default:
+		return v.Float()
+	}
+}
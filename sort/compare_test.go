@@ -0,0 +1,56 @@
+package sort
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"reflect"
+	gosort "sort"
+	"testing"
+)
+
+func TestCompareNumbers(t *testing.T) {
+	assert.Equal(t, 0, Compare(1, 1.0))
+	assert.Equal(t, 0, Compare(uint8(1), int64(1)))
+	assert.Equal(t, -1, Compare(1, 1.5))
+	assert.Equal(t, 1, Compare(float32(2), uint(1)))
+	assert.Equal(t, -1, Compare(-1, uint64(math.MaxUint64)))
+	assert.Equal(t, 1, Compare(uint64(math.MaxUint64), int64(math.MaxInt64)))
+	assert.Equal(t, -1, Compare(math.NaN(), 0))
+}
+
+func TestCompareValues(t *testing.T) {
+	assert.Equal(t, -1, Compare("a", "b"))
+	assert.Equal(t, 0, Compare(nil, nil))
+	assert.Equal(t, -1, Compare(nil, 0))
+	assert.Equal(t, 1, Compare(false, nil))
+	assert.Equal(t, 1, Compare(true, false))
+	assert.Equal(t, -1, Compare([]int{1, 2}, []int{1, 3}))
+	assert.Equal(t, -1, Compare([]int{1, 2}, []int{1, 2, 0}))
+	assert.Equal(t, 0, Compare([]interface{}{1, "a"}, []interface{}{1.0, "a"}))
+	assert.Equal(t, -1, Compare(1, "1"))
+	assert.Equal(t, 1, Compare("1", 1))
+}
+
+type IntSlice []int
+
+type row struct {
+	Items []interface{}
+	label string
+}
+
+func TestCompareTypes(t *testing.T) {
+	ours, theirs := IntSlice{1}, gosort.IntSlice{1}
+	assert.Equal(t, "sort.IntSlice", reflect.TypeOf(theirs).String())
+	assert.Equal(t, -Compare(ours, theirs), Compare(theirs, ours))
+	assert.NotEqual(t, 0, Compare(ours, theirs))
+	assert.Equal(t, -Compare([]IntSlice{}, []gosort.IntSlice{}), Compare([]gosort.IntSlice{}, []IntSlice{}))
+	assert.NotEqual(t, 0, Compare([]IntSlice{}, []gosort.IntSlice{}))
+}
+
+func TestCompareStructs(t *testing.T) {
+	assert.Equal(t, -1, Compare(row{Items: []interface{}{1, "a"}}, row{Items: []interface{}{2}}))
+	assert.Equal(t, 1, Compare(row{Items: []interface{}{1}, label: "b"}, row{Items: []interface{}{1.0}, label: "a"}))
+	assert.Equal(t, 0, Compare(row{Items: []interface{}{1}}, row{Items: []interface{}{1}}))
+	assert.Equal(t, 0, Compare(TestCompareStructs, TestCompareStructs))
+	assert.Panics(t, func() { Compare(map[string]int{}, map[string]int{}) })
+}
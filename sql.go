@@ -0,0 +1,124 @@
+package collection
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FromRows reads all the rows into a collection of row collections keyed by their position.
+// Each row is keyed by the column names in column order, and its values are converted
+// to natural Go types, see LazyFromRows. The rows are closed when they are all read.
+func FromRows(rows *sql.Rows) (Collection, error) {
+	lazy := LazyFromRows(rows)
+	c := lazy.Collect()
+	if err := lazy.Err(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// LazyFromRows streams the rows as row collections keyed by their position, only the current row
+// is kept in memory. Each row is keyed by the column names in column order. Text returned as bytes
+// is converted to a string, or to an int64, uint64, float64, or bool when the database type of the column
+// is an integer, unsigned integer, floating point, or boolean type. Exact DECIMAL and NUMERIC values stay strings so
+// they keep their precision. Bytes of binary columns are kept as []byte. The rows are closed when the
+// stream ends, and Err reports the error that ended the stream early.
+func LazyFromRows(rows *sql.Rows) LazyCollection {
+	var columns []*sql.ColumnType
+	var names []string
+	done := false
+	index := 0
+
+	l := Lazy(nil)
+	end := func(err error) (interface{}, interface{}, bool) {
+		*l.err = err
+		done = true
+		rows.Close()
+		return nil, nil, false
+	}
+
+	l.next = func() (interface{}, interface{}, bool) {
+		if done {
+			return nil, nil, false
+		}
+
+		if columns == nil {
+			var err error
+			if columns, err = rows.ColumnTypes(); err != nil {
+				return end(err)
+			}
+
+			for _, column := range columns {
+				for _, name := range names {
+					if name == column.Name() {
+						return end(fmt.Errorf("collection: duplicate column %q", name))
+					}
+				}
+				names = append(names, column.Name())
+			}
+		}
+
+		if !rows.Next() {
+			return end(rows.Err())
+		}
+
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		if err := rows.Scan(pointers...); err != nil {
+			return end(err)
+		}
+
+		row := collect{}
+		for i, column := range columns {
+			row.keys = append(row.keys, names[i])
+			row.values = append(row.values, rowValue(values[i], column.DatabaseTypeName()))
+		}
+
+		index++
+		return row, index - 1, true
+	}
+	return l
+}
+
+// rowValue converts the bytes of a column value to the natural type of the database type
+func rowValue(value interface{}, databaseType string) interface{} {
+	b, ok := value.([]byte)
+	if !ok {
+		return value
+	}
+
+	databaseType = strings.ToUpper(databaseType)
+	unsigned := strings.Contains(databaseType, "UNSIGNED")
+	databaseType = strings.TrimSpace(strings.Replace(databaseType, "UNSIGNED", "", 1))
+	if i := strings.IndexAny(databaseType, "( "); i > -1 {
+		databaseType = databaseType[:i]
+	}
+
+	switch databaseType {
+	case "BLOB", "TINYBLOB", "MEDIUMBLOB", "LONGBLOB", "BINARY", "VARBINARY", "BYTEA":
+		return append([]byte{}, b...)
+	case "TINYINT", "SMALLINT", "MEDIUMINT", "INT", "INTEGER", "BIGINT", "INT2", "INT4", "INT8", "SERIAL", "BIGSERIAL":
+		if unsigned {
+			if n, err := strconv.ParseUint(string(b), 10, 64); err == nil {
+				return n
+			}
+		} else if n, err := strconv.ParseInt(string(b), 10, 64); err == nil {
+			return n
+		}
+	case "FLOAT", "FLOAT4", "FLOAT8", "DOUBLE", "REAL":
+		if f, err := strconv.ParseFloat(string(b), 64); err == nil {
+			return f
+		}
+	case "BOOL", "BOOLEAN":
+		if v, err := strconv.ParseBool(string(b)); err == nil {
+			return v
+		}
+	}
+	return string(b)
+}
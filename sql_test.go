@@ -0,0 +1,164 @@
+package collection
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// stubDriver serves fixed tables from memory, the query is the table name
+type stubDriver struct{}
+
+type stubTable struct {
+	columns []string
+	types   []string
+	rows    [][]driver.Value
+	err     error
+}
+
+var stubTables = map[string]stubTable{
+	"users": {
+		columns: []string{"id", "name", "score", "active", "avatar", "created"},
+		types:   []string{"BIGINT", "VARCHAR(20)", "DECIMAL(5,2)", "BOOLEAN", "BLOB", "TIMESTAMP"},
+		rows: [][]driver.Value{
+			{int64(1), []byte("Ann"), []byte("9.50"), []byte("true"), []byte{0x1, 0x2}, time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)},
+			{[]byte("2"), "Bob", 7.25, false, nil, nil},
+		},
+	},
+	"broken": {
+		columns: []string{"id"},
+		types:   []string{"INT"},
+		rows:    [][]driver.Value{{int64(1)}},
+		err:     errors.New("connection lost"),
+	},
+	"joined": {
+		columns: []string{"id", "id"},
+		types:   []string{"INT", "INT"},
+		rows:    [][]driver.Value{{int64(1), int64(2)}},
+	},
+}
+
+func init() {
+	sql.Register("collection-stub", stubDriver{})
+}
+
+func (stubDriver) Open(name string) (driver.Conn, error) { return stubConn{}, nil }
+
+type stubConn struct{}
+
+func (stubConn) Prepare(query string) (driver.Stmt, error) { return stubStmt{query}, nil }
+func (stubConn) Close() error                              { return nil }
+func (stubConn) Begin() (driver.Tx, error)                 { return nil, errors.New("not supported") }
+
+type stubStmt struct {
+	query string
+}
+
+func (s stubStmt) Close() error  { return nil }
+func (s stubStmt) NumInput() int { return 0 }
+func (s stubStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+func (s stubStmt) Query(args []driver.Value) (driver.Rows, error) {
+	table, ok := stubTables[s.query]
+	if !ok {
+		return nil, errors.New("unknown table")
+	}
+	return &stubRows{table: table}, nil
+}
+
+type stubRows struct {
+	table stubTable
+	next  int
+}
+
+func (r *stubRows) Columns() []string                       { return r.table.columns }
+func (r *stubRows) ColumnTypeDatabaseTypeName(i int) string { return r.table.types[i] }
+func (r *stubRows) Close() error                            { return nil }
+func (r *stubRows) Next(dest []driver.Value) error {
+	if r.next == len(r.table.rows) {
+		if r.table.err != nil {
+			return r.table.err
+		}
+		return io.EOF
+	}
+
+	copy(dest, r.table.rows[r.next])
+	r.next++
+	return nil
+}
+
+func query(t *testing.T, table string) *sql.Rows {
+	db, err := sql.Open("collection-stub", "")
+	assert.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	rows, err := db.Query(table)
+	assert.NoError(t, err)
+	return rows
+}
+
+func TestFromRows(t *testing.T) {
+	c, err := FromRows(query(t, "users"))
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{0, 1}, c.Keys().All())
+
+	ann := c.GetValue(0).(Collection)
+	assert.Equal(t, []interface{}{"id", "name", "score", "active", "avatar", "created"}, ann.Keys().All())
+	assert.Equal(t, []interface{}{int64(1), "Ann", "9.50", true, []byte{0x1, 0x2}, time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)}, ann.Values().All())
+
+	bob := c.GetValue(1).(Collection)
+	assert.Equal(t, []interface{}{int64(2), "Bob", 7.25, false, nil, nil}, bob.Values().All())
+
+	assert.Equal(t, "12345678901234567.89", rowValue([]byte("12345678901234567.89"), "NUMERIC(20,2)"))
+	assert.Equal(t, 2.5, rowValue([]byte("2.5"), "double precision"))
+	assert.Equal(t, uint64(18446744073709551615), rowValue([]byte("18446744073709551615"), "UNSIGNED BIGINT"))
+	assert.Equal(t, uint64(7), rowValue([]byte("7"), "UNSIGNED INT"))
+	assert.Equal(t, uint64(7), rowValue([]byte("7"), "int(10) unsigned"))
+	assert.Equal(t, int64(-7), rowValue([]byte("-7"), "INT"))
+	assert.Equal(t, "-7", rowValue([]byte("-7"), "UNSIGNED INT"))
+
+	_, err = FromRows(query(t, "broken"))
+	assert.EqualError(t, err, "connection lost")
+
+	_, err = FromRows(query(t, "joined"))
+	assert.EqualError(t, err, `collection: duplicate column "id"`)
+}
+
+func TestFromRowsLarge(t *testing.T) {
+	const size = 50000
+	table := stubTable{columns: []string{"id"}, types: []string{"BIGINT"}}
+	for i := 0; i < size; i++ {
+		table.rows = append(table.rows, []driver.Value{[]byte(strconv.Itoa(i))})
+	}
+	stubTables["large"] = table
+	defer delete(stubTables, "large")
+
+	c, err := FromRows(query(t, "large"))
+	assert.NoError(t, err)
+	assert.Equal(t, size, c.Size())
+	assert.Equal(t, int64(size-1), c.GetValue(size-1).(Collection).GetValue("id"))
+}
+
+func TestLazyFromRows(t *testing.T) {
+	var names []interface{}
+	lazy := LazyFromRows(query(t, "users"))
+	lazy.Each(func(value interface{}, key interface{}, index int) {
+		names = append(names, value.(Collection).GetValue("name"))
+	})
+	assert.NoError(t, lazy.Err())
+	assert.Equal(t, []interface{}{"Ann", "Bob"}, names)
+
+	count := 0
+	broken := LazyFromRows(query(t, "broken"))
+	broken.Each(func(value interface{}, key interface{}, index int) { count++ })
+	assert.Equal(t, 1, count)
+	assert.EqualError(t, broken.Err(), "connection lost")
+
+	assert.NoError(t, LazyCollection{}.Err())
+}
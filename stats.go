@@ -0,0 +1,191 @@
+package collection
+
+import (
+	"github.com/habibimustafa/collection/arr"
+	"github.com/habibimustafa/collection/deep"
+	"github.com/habibimustafa/collection/sort"
+	"math"
+	gosort "sort"
+	"strconv"
+)
+
+// Frequencies counts how many times each value appears, the values become the keys.
+// Numbers of different types with the same value are counted together under the first one.
+// Items are ordered by the most frequent first, ties keep the order of first appearance.
+// Nil values are skipped because a key must not be nil.
+func (c collect) Frequencies() Collection {
+	var keys []interface{}
+	var counts []interface{}
+	indexes := map[uint64][]int{}
+	for _, value := range c.values {
+		if value == nil {
+			continue
+		}
+
+		hash := deep.HashNumeric(value)
+		found := false
+		for _, i := range indexes[hash] {
+			if deep.EqualNumeric(keys[i], value) {
+				counts[i] = counts[i].(int) + 1
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			indexes[hash] = append(indexes[hash], len(keys))
+			keys = append(keys, value)
+			counts = append(counts, 1)
+		}
+	}
+
+	order := make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+	gosort.SliceStable(order, func(i, j int) bool {
+		return counts[order[i]].(int) > counts[order[j]].(int)
+	})
+
+	f := collect{equaler: NumericKeys{}}
+	for _, i := range order {
+		f.keys = append(f.keys, keys[i])
+		f.values = append(f.values, counts[i])
+	}
+	return f
+}
+
+// Histogram counts the numeric values falling into the given number of equal width buckets
+// between the smallest and the largest value. The keys are the bucket ranges like "[0, 2.5)",
+// the last bucket includes its upper bound. It panics when a value is NaN or infinite.
+func (c collect) Histogram(buckets int) Collection {
+	if buckets <= 0 {
+		panic("the number of buckets must be greater than zero")
+	}
+
+	numbers := c.numbers("the values must be numbers to build a histogram")
+	if len(numbers) == 0 {
+		return collect{}
+	}
+
+	min, max := numbers[0], numbers[0]
+	for _, n := range numbers {
+		if math.IsNaN(n) || math.IsInf(n, 0) {
+			panic("the values must be finite numbers to build a histogram")
+		}
+		min, max = math.Min(min, n), math.Max(max, n)
+	}
+
+	// dividing before subtracting keeps the width finite for the widest ranges
+	width := max/float64(buckets) - min/float64(buckets)
+	if width == 0 {
+		width = 1
+	}
+
+	counts := make([]int, buckets)
+	for _, n := range numbers {
+		position := n/width - min/width
+		if !(position < float64(buckets-1)) {
+			position = float64(buckets - 1)
+		}
+		counts[int(math.Max(position, 0))]++
+	}
+
+	h := collect{}
+	for i, count := range counts {
+		lower, upper := min+float64(i)*width, min+float64(i+1)*width
+		closing := ")"
+		if i == buckets-1 {
+			closing = "]"
+		}
+
+		h.keys = append(h.keys, "["+formatFloat(lower)+", "+formatFloat(upper)+closing)
+		h.values = append(h.values, count)
+	}
+	return h
+}
+
+// TopN gets the n items with the largest callback results, largest first.
+// The values themselves are compared when the callback is nil.
+func (c collect) TopN(n int, callback func(value interface{}, key interface{}, index int) interface{}) Collection {
+	return c.rank(n, callback, true)
+}
+
+// BottomN gets the n items with the smallest callback results, smallest first.
+// The values themselves are compared when the callback is nil.
+func (c collect) BottomN(n int, callback func(value interface{}, key interface{}, index int) interface{}) Collection {
+	return c.rank(n, callback, false)
+}
+
+// Quantiles gets the k-quantiles of the numeric values, including the minimum and the maximum.
+// The keys are the fractions from 0 to 1, values are linearly interpolated between the closest ranks.
+func (c collect) Quantiles(k int) Collection {
+	if k <= 0 {
+		panic("the number of quantiles must be greater than zero")
+	}
+
+	numbers := c.numbers("the values must be numbers to compute quantiles")
+	if len(numbers) == 0 {
+		return collect{}
+	}
+	gosort.Float64s(numbers)
+
+	q := collect{}
+	for i := 0; i <= k; i++ {
+		fraction := float64(i) / float64(k)
+		position := fraction * float64(len(numbers)-1)
+		lower := int(math.Floor(position))
+		upper := int(math.Ceil(position))
+		value := numbers[lower] + (numbers[upper]-numbers[lower])*(position-float64(lower))
+
+		q.keys = append(q.keys, fraction)
+		q.values = append(q.values, value)
+	}
+	return q
+}
+
+func (c collect) rank(n int, callback func(value interface{}, key interface{}, index int) interface{}, descending bool) Collection {
+	if callback == nil {
+		callback = func(value interface{}, key interface{}, index int) interface{} { return value }
+	}
+
+	ranks := make([]interface{}, c.Size())
+	order := make(arr.Array, c.Size())
+	for i := range order {
+		order[i] = i
+		ranks[i] = callback(c.values[i], c.keys[i], i)
+	}
+
+	gosort.SliceStable(order, func(i, j int) bool {
+		cmp := sort.Compare(ranks[order[i].(int)], ranks[order[j].(int)])
+		if descending {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+
+	if n < 0 {
+		n = 0
+	}
+	if n < len(order) {
+		order = order[:n]
+	}
+	return c.pick(order)
+}
+
+// numbers converts all values into float64, it panics with the message when a value is not a number
+func (c collect) numbers(message string) []float64 {
+	numbers := make([]float64, 0, c.Size())
+	for _, value := range c.values {
+		n, ok := toFloat(value)
+		if !ok {
+			panic(message)
+		}
+		numbers = append(numbers, n)
+	}
+	return numbers
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,100 @@
+package collection
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestCollectionFrequencies(t *testing.T) {
+	f := Collect([]interface{}{"a", 1, "b", 1.0, "a", int64(1), "c"}).Frequencies()
+
+	assert.Equal(t, []interface{}{1, "a", "b", "c"}, f.Keys().All())
+	assert.Equal(t, []interface{}{3, 2, 1, 1}, f.Values().All())
+	assert.Equal(t, 3, f.GetValue(1.0))
+	assert.Equal(t, 0, Collect(nil).Frequencies().Size())
+
+	withNil := Collect([]interface{}{nil, "a", nil}).Frequencies()
+	assert.Equal(t, []interface{}{"a"}, withNil.Keys().All())
+	assert.NotContains(t, withNil.Keys().All(), nil)
+}
+
+func TestCollectionHistogram(t *testing.T) {
+	h := Collect([]int{0, 1, 2, 5, 7, 10}).Histogram(4)
+
+	assert.Equal(t, []interface{}{"[0, 2.5)", "[2.5, 5)", "[5, 7.5)", "[7.5, 10]"}, h.Keys().All())
+	assert.Equal(t, []interface{}{3, 0, 2, 1}, h.Values().All())
+
+	same := Collect([]float64{3, 3}).Histogram(2)
+	assert.Equal(t, []interface{}{2, 0}, same.Values().All())
+
+	assert.Equal(t, 0, Collect(nil).Histogram(3).Size())
+	assert.PanicsWithValue(t, "the number of buckets must be greater than zero", func() { Collect([]int{1}).Histogram(0) })
+	assert.PanicsWithValue(t, "the values must be numbers to build a histogram", func() { Collect(arrString).Histogram(2) })
+	assert.PanicsWithValue(t, "the values must be finite numbers to build a histogram", func() { Collect([]float64{1, math.NaN()}).Histogram(2) })
+	assert.PanicsWithValue(t, "the values must be finite numbers to build a histogram", func() { Collect([]float64{math.Inf(-1), 1}).Histogram(2) })
+
+	wide := Collect([]float64{-math.MaxFloat64, 0, math.MaxFloat64}).Histogram(2)
+	assert.Equal(t, []interface{}{1, 2}, wide.Values().All())
+}
+
+func TestCollectionTopNAndBottomN(t *testing.T) {
+	c := Collect(map[string]interface{}{"a": 3, "b": 1.5, "c": uint8(7), "d": 3.0})
+
+	top := c.TopN(3, nil)
+	assert.Equal(t, []interface{}{"c", "a", "d"}, top.Keys().All())
+
+	bottom := c.BottomN(2, nil)
+	assert.Equal(t, []interface{}{"b", "a"}, bottom.Keys().All())
+
+	byLength := Collect(arrString).TopN(10, func(value interface{}, key interface{}, index int) interface{} {
+		return len(value.(string))
+	})
+	assert.Equal(t, []interface{}{"Hello", "World", "Ready", "Are", "You"}, byLength.Values().All())
+	assert.Equal(t, 0, c.TopN(0, nil).Size())
+}
+
+func TestCollectionTopNNestedCollections(t *testing.T) {
+	rows := Collect([]interface{}{
+		Collect([]int{2, 1}),
+		Collect([]int{3}),
+		CollectFold(map[string]int{"a": 1}),
+		CollectFold(map[string]int{"a": 1}),
+		Collect([]int{2}),
+	})
+
+	assert.Equal(t, []interface{}{2, 3, 0}, rows.TopN(3, nil).Keys().All())
+	assert.Equal(t, []interface{}{4, 1, 0, 2, 3}, rows.SortBy(func(value interface{}, key interface{}, index int) interface{} {
+		return value
+	}).Keys().All())
+}
+
+func TestCollectionQuantiles(t *testing.T) {
+	q := Collect([]int{7, 1, 3, 5, 9}).Quantiles(4)
+
+	assert.Equal(t, []interface{}{0.0, 0.25, 0.5, 0.75, 1.0}, q.Keys().All())
+	assert.Equal(t, []interface{}{1.0, 3.0, 5.0, 7.0, 9.0}, q.Values().All())
+
+	half := Collect([]float64{1, 2}).Quantiles(2)
+	assert.Equal(t, 1.5, half.GetValue(0.5))
+
+	assert.Equal(t, 0, Collect(nil).Quantiles(4).Size())
+	assert.PanicsWithValue(t, "the number of quantiles must be greater than zero", func() { Collect([]int{1}).Quantiles(0) })
+}
+
+func TestCollectionMarshalJSON(t *testing.T) {
+	c := Collect(arrMap).Append("Tags", Collect([]string{"a", "b"}))
+
+	encoded, err := json.Marshal(c)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"Age":28,"First Name":"John","Last Name":"Doe","Tags":{"0":"a","1":"b"}}`, string(encoded))
+
+	encoded, err = json.Marshal(Collect([]int{1, 1, 2}).Frequencies())
+	assert.NoError(t, err)
+	assert.Equal(t, `{"1":2,"2":1}`, string(encoded))
+
+	encoded, err = json.Marshal(Collect(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, `{}`, string(encoded))
+}
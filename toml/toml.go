@@ -0,0 +1,300 @@
+// Package toml implements TOML encoding and decoding for collections keeping the order of the keys.
+// Importing it registers the ".toml" extension for collection.FromFile.
+package toml
+
+import (
+	"bytes"
+	"fmt"
+	burntsushi "github.com/BurntSushi/toml"
+	"github.com/habibimustafa/collection"
+	"math"
+	"reflect"
+	"regexp"
+	gosort "sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func init() {
+	collection.RegisterFormat(".toml", Decode)
+}
+
+// Decode decodes a TOML document into a collection keeping the order of the keys in the document.
+// Tables become collections, arrays become []interface{}, and arrays of tables become
+// []interface{} of collections. Integers are int64 and floats are float64.
+func Decode(data []byte) (collection.Collection, error) {
+	var document map[string]interface{}
+	meta, err := burntsushi.Decode(string(data), &document)
+	if err != nil {
+		return nil, fmt.Errorf("toml: %w", err)
+	}
+
+	// order holds the position where every key path first appears in the document,
+	// items of arrays of tables share the path of their array
+	order := map[string]int{}
+	for i, key := range meta.Keys() {
+		for j := 1; j <= len(key); j++ {
+			path := strings.Join(key[:j], "\x00")
+			if _, ok := order[path]; !ok {
+				order[path] = i
+			}
+		}
+	}
+
+	return table(document, "", order), nil
+}
+
+func table(m map[string]interface{}, path string, order map[string]int) collection.Collection {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	position := func(key string) int {
+		if i, ok := order[childPath(path, key)]; ok {
+			return i
+		}
+		return math.MaxInt32
+	}
+
+	gosort.Slice(keys, func(i, j int) bool {
+		if position(keys[i]) != position(keys[j]) {
+			return position(keys[i]) < position(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+
+	c := collection.Collect(nil)
+	for _, key := range keys {
+		c = c.Append(key, value(m[key], childPath(path, key), order))
+	}
+	return c
+}
+
+func value(v interface{}, path string, order map[string]int) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return table(v, path, order)
+	case []map[string]interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = table(item, path, order)
+		}
+		return items
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = value(item, path, order)
+		}
+		return items
+	default:
+		return v
+	}
+}
+
+func childPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "\x00" + key
+}
+
+// Encode encodes a collection as a TOML document keeping the order of the items.
+// Keys are formatted with fmt.Sprint. Nested collections and maps become tables,
+// and slices holding only collections and maps become arrays of tables.
+// It fails on nil values, TOML has no null.
+func Encode(c collection.Collection) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := encodeTable(&buf, nil, c); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func encodeTable(buf *bytes.Buffer, path []string, c collection.Collection) error {
+	keys, values := c.Keys(), c.Values()
+
+	// plain values must come before the sub tables, otherwise they would belong to the last sub table
+	for i, key := range keys {
+		if isTable(values[i]) || isTableArray(values[i]) {
+			continue
+		}
+
+		encoded, err := encodeValue(values[i])
+		if err != nil {
+			return fmt.Errorf("toml: key %q: %w", strings.Join(append(path, fmt.Sprint(key)), "."), err)
+		}
+		fmt.Fprintf(buf, "%s = %s\n", quoteKey(fmt.Sprint(key)), encoded)
+	}
+
+	for i, key := range keys {
+		tablePath := append(append([]string{}, path...), fmt.Sprint(key))
+		switch {
+		case isTable(values[i]):
+			fmt.Fprintf(buf, "\n[%s]\n", joinKeys(tablePath))
+			if err := encodeTable(buf, tablePath, asCollection(values[i])); err != nil {
+				return err
+			}
+		case isTableArray(values[i]):
+			val := reflect.ValueOf(values[i])
+			for j := 0; j < val.Len(); j++ {
+				fmt.Fprintf(buf, "\n[[%s]]\n", joinKeys(tablePath))
+				if err := encodeTable(buf, tablePath, asCollection(val.Index(j).Interface())); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func encodeValue(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", fmt.Errorf("cannot encode nil")
+	case string:
+		return quote(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	}
+
+	if isTable(v) {
+		c := asCollection(v)
+		keys, values := c.Keys(), c.Values()
+		entries := make([]string, len(keys))
+		for i, key := range keys {
+			encoded, err := encodeValue(values[i])
+			if err != nil {
+				return "", err
+			}
+			entries[i] = quoteKey(fmt.Sprint(key)) + " = " + encoded
+		}
+		return "{" + strings.Join(entries, ", ") + "}", nil
+	}
+
+	val := reflect.ValueOf(v)
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(val.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if val.Uint() > math.MaxInt64 {
+			return "", fmt.Errorf("integer %d overflows a TOML integer", val.Uint())
+		}
+		return strconv.FormatUint(val.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return formatFloat(val.Float()), nil
+	case reflect.String:
+		return quote(val.String()), nil
+	case reflect.Slice, reflect.Array:
+		items := make([]string, val.Len())
+		for i := range items {
+			encoded, err := encodeValue(val.Index(i).Interface())
+			if err != nil {
+				return "", err
+			}
+			items[i] = encoded
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	default:
+		return "", fmt.Errorf("cannot encode %T", v)
+	}
+}
+
+func formatFloat(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "nan"
+	case math.IsInf(f, 1):
+		return "inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	}
+
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".eE") {
+		s += ".0"
+	}
+	return s
+}
+
+// isTable is the value a collection or a map
+func isTable(v interface{}) bool {
+	if _, ok := v.(collection.Collection); ok {
+		return true
+	}
+	return v != nil && reflect.ValueOf(v).Kind() == reflect.Map
+}
+
+// isTableArray is the value a non-empty slice or array holding only tables
+func isTableArray(v interface{}) bool {
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array || val.Len() == 0 {
+		return false
+	}
+
+	for i := 0; i < val.Len(); i++ {
+		if !isTable(val.Index(i).Interface()) {
+			return false
+		}
+	}
+	return true
+}
+
+func asCollection(v interface{}) collection.Collection {
+	if c, ok := v.(collection.Collection); ok {
+		return c
+	}
+	return collection.Collect(v)
+}
+
+var bareKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+func quoteKey(key string) string {
+	if bareKey.MatchString(key) {
+		return key
+	}
+	return quote(key)
+}
+
+func joinKeys(path []string) string {
+	keys := make([]string, len(path))
+	for i, key := range path {
+		keys[i] = quoteKey(key)
+	}
+	return strings.Join(keys, ".")
+}
+
+// quote formats the string as a TOML basic string
+func quote(s string) string {
+	var buf strings.Builder
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\t':
+			buf.WriteString(`\t`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\r':
+			buf.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&buf, `\u%04X`, r)
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+	return buf.String()
+}
@@ -0,0 +1,108 @@
+package toml
+
+import (
+	"github.com/habibimustafa/collection"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const document = `title = "Example"
+version = 2
+
+[server]
+port = 8080
+host = "localhost"
+
+[server.tls]
+enabled = true
+
+[[products]]
+name = "Hammer"
+sku = 738594937
+
+[[products]]
+name = "Nail"
+sizes = [1.5, 2.0]
+`
+
+func TestDecode(t *testing.T) {
+	c, err := Decode([]byte(document))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []interface{}{"title", "version", "server", "products"}, c.Keys().All())
+	assert.Equal(t, int64(2), c.GetValue("version"))
+
+	server := c.GetValue("server").(collection.Collection)
+	assert.Equal(t, []interface{}{"port", "host", "tls"}, server.Keys().All())
+	assert.Equal(t, true, server.GetValue("tls").(collection.Collection).GetValue("enabled"))
+
+	products := c.GetValue("products").([]interface{})
+	assert.Len(t, products, 2)
+	assert.Equal(t, []interface{}{"name", "sku"}, products[0].(collection.Collection).Keys().All())
+	assert.Equal(t, []interface{}{1.5, 2.0}, products[1].(collection.Collection).GetValue("sizes"))
+
+	_, err = Decode([]byte("a = "))
+	assert.Error(t, err)
+}
+
+func TestEncode(t *testing.T) {
+	c, err := Decode([]byte(document))
+	assert.NoError(t, err)
+
+	encoded, err := Encode(c)
+	assert.NoError(t, err)
+	assert.Equal(t, `title = "Example"
+version = 2
+
+[server]
+port = 8080
+host = "localhost"
+
+[server.tls]
+enabled = true
+
+[[products]]
+name = "Hammer"
+sku = 738594937
+
+[[products]]
+name = "Nail"
+sizes = [1.5, 2.0]
+`, string(encoded))
+
+	decoded, err := Decode(encoded)
+	assert.NoError(t, err)
+	assert.True(t, c.DeepEquals(decoded))
+}
+
+func TestEncodeValues(t *testing.T) {
+	c := collection.Collect(map[string]interface{}{
+		"a key":  "line\n\"quoted\"",
+		"inline": []interface{}{collection.Collect(map[string]int{"x": 1}), 2},
+		"time":   time.Date(2021, 5, 1, 10, 0, 0, 0, time.UTC),
+		"whole":  3.0,
+	})
+
+	encoded, err := Encode(c)
+	assert.NoError(t, err)
+	assert.Equal(t, `"a key" = "line\n\"quoted\""
+inline = [{x = 1}, 2]
+time = 2021-05-01T10:00:00Z
+whole = 3.0
+`, string(encoded))
+
+	_, err = Encode(collection.Collect(map[string]interface{}{"a": map[string]interface{}{"b": nil}}))
+	assert.EqualError(t, err, `toml: key "a.b": cannot encode nil`)
+}
+
+func TestFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	assert.NoError(t, os.WriteFile(path, []byte(document), 0o600))
+
+	c, err := collection.FromFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "Example", c.GetValue("title"))
+}
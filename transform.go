@@ -0,0 +1,103 @@
+package collection
+
+import (
+	"reflect"
+)
+
+// Reindex resets the keys to the positions of the items
+func (c collect) Reindex() Collection {
+	keys := make([]interface{}, c.Size())
+	for i := range keys {
+		keys[i] = i
+	}
+	return c.with(keys, append([]interface{}{}, c.values...))
+}
+
+// MapValues converts each value into a new value, the keys are kept
+func (c collect) MapValues(callback func(value interface{}, key interface{}, index int) interface{}) Collection {
+	values := make([]interface{}, c.Size())
+	for i := range c.keys {
+		values[i] = callback(c.values[i], c.keys[i], i)
+	}
+	return c.with(append([]interface{}{}, c.keys...), values)
+}
+
+// MapKeys converts each key into a new key, the values are kept.
+// It panics when two items get the same key.
+func (c collect) MapKeys(callback func(value interface{}, key interface{}, index int) interface{}) Collection {
+	mapped := c.with(nil, nil).build()
+	for i := range c.keys {
+		key := callback(c.values[i], c.keys[i], i)
+		if err := mapped.check(key); err != nil {
+			panic(err.Error())
+		}
+		mapped.add(key, c.values[i])
+	}
+	return mapped.collect
+}
+
+// Flip swaps the keys with their values. When values repeat, the key keeps
+// the position of its first appearance and the value of its last one.
+// It panics when a value cannot be a key, such as nil, a slice, or a map.
+func (c collect) Flip() Collection {
+	flipped := collect{}.build()
+	for i := range c.keys {
+		flipped.put(c.values[i], c.keys[i])
+	}
+	return flipped.collect
+}
+
+// MapWithKeys converts each item into the entries of the returned map, the entries of each map
+// are added in sorted key order. When keys repeat, the key keeps the position of its first
+// appearance and the value of its last one.
+func (c collect) MapWithKeys(callback func(value interface{}, key interface{}, index int) map[interface{}]interface{}) Collection {
+	mapped := c.with(nil, nil).build()
+	for i := range c.keys {
+		entries := Collect(callback(c.values[i], c.keys[i], i))
+		values := entries.Values()
+		for j, key := range entries.Keys() {
+			mapped.put(key, values[j])
+		}
+	}
+	return mapped.collect
+}
+
+// FlatMap converts each item into a collection, slice, or array and flattens the results
+// by one level, other results are added as they are. The keys are the positions of the values.
+func (c collect) FlatMap(callback func(value interface{}, key interface{}, index int) interface{}) Collection {
+	flattened := collect{}
+	for i := range c.keys {
+		result := callback(c.values[i], c.keys[i], i)
+		if nested, ok := result.(Collection); ok {
+			for _, value := range nested.Values() {
+				flattened = flattened.push(value)
+			}
+			continue
+		}
+
+		val := reflect.ValueOf(result)
+		if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+			flattened = flattened.push(result)
+			continue
+		}
+
+		for j := 0; j < val.Len(); j++ {
+			flattened = flattened.push(val.Index(j).Interface())
+		}
+	}
+	return flattened
+}
+
+// put sets the value of an existing key, or adds the key to the end after validating it.
+// It updates the values in place, so it must only be used on a collection being built.
+func (c collect) put(key interface{}, value interface{}) collect {
+	if index := c.indexOf(key); index > -1 {
+		c.values[index] = value
+		return c
+	}
+
+	c.validateKey(key)
+	c.keys = append(c.keys, key)
+	c.values = append(c.values, value)
+	return c
+}
@@ -0,0 +1,94 @@
+package collection
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestCollectionReindex(t *testing.T) {
+	filtered := Collect(arrString).Filter(hasLetterO)
+	assert.Equal(t, []interface{}{0, 1, 3}, filtered.Keys().All())
+
+	reindexed := filtered.Reindex()
+	assert.Equal(t, []interface{}{0, 1, 2}, reindexed.Keys().All())
+	assert.Equal(t, []interface{}{"Hello", "World", "You"}, reindexed.Values().All())
+	assert.Equal(t, 0, Collect(nil).Reindex().Size())
+}
+
+func TestCollectionMapValuesAndMapKeys(t *testing.T) {
+	upper := Collect(arrMap).MapValues(func(value interface{}, key interface{}, index int) interface{} {
+		return strings.ToUpper(fmt.Sprint(value))
+	})
+	assert.Equal(t, []interface{}{"Age", "First Name", "Last Name"}, upper.Keys().All())
+	assert.Equal(t, []interface{}{"28", "JOHN", "DOE"}, upper.Values().All())
+
+	snake := Collect(arrMap).MapKeys(func(value interface{}, key interface{}, index int) interface{} {
+		return strings.ReplaceAll(strings.ToLower(key.(string)), " ", "_")
+	})
+	assert.Equal(t, []interface{}{"age", "first_name", "last_name"}, snake.Keys().All())
+	assert.Equal(t, Collect(arrMap).Values(), snake.Values())
+
+	assert.PanicsWithValue(t, "the new key is already exists", func() {
+		Collect(arrString).MapKeys(func(value interface{}, key interface{}, index int) interface{} {
+			return len(value.(string))
+		})
+	})
+}
+
+func TestCollectionFlip(t *testing.T) {
+	flipped := Collect([]string{"a", "b", "a"}).Flip()
+	assert.Equal(t, []interface{}{"a", "b"}, flipped.Keys().All())
+	assert.Equal(t, []interface{}{2, 1}, flipped.Values().All())
+
+	assert.PanicsWithValue(t, "the new key type is different", func() { Collect(arrMap).Flip() })
+	assert.PanicsWithValue(t, "the new key is not comparable, use a key equaler such as DeepKeys", func() {
+		Collect([]interface{}{[]int{1}, []int{1}}).Flip()
+	})
+	assert.PanicsWithValue(t, "the new key must not be nil", func() { Collect([]interface{}{nil}).Flip() })
+
+	values := make([]int, 20000)
+	for i := range values {
+		values[i] = i % 10000
+	}
+	flipped = Collect(values).Flip()
+	assert.Equal(t, 10000, flipped.Size())
+	assert.Equal(t, 10009, flipped.GetValue(9))
+}
+
+func TestCollectionMapWithKeys(t *testing.T) {
+	people := []map[string]interface{}{
+		{"email": "ann@example.com", "name": "Ann"},
+		{"email": "bob@example.com", "name": "Bob"},
+	}
+
+	byEmail := Collect(people).MapWithKeys(func(value interface{}, key interface{}, index int) map[interface{}]interface{} {
+		person := value.(map[string]interface{})
+		return map[interface{}]interface{}{person["email"]: person["name"], strings.ToLower(person["name"].(string)): index}
+	})
+	assert.Equal(t, []interface{}{"ann", "ann@example.com", "bob", "bob@example.com"}, byEmail.Keys().All())
+	assert.Equal(t, []interface{}{0, "Ann", 1, "Bob"}, byEmail.Values().All())
+
+	repeated := Collect(arrString).MapWithKeys(func(value interface{}, key interface{}, index int) map[interface{}]interface{} {
+		return map[interface{}]interface{}{len(value.(string)): value}
+	})
+	assert.Equal(t, []interface{}{5, 3}, repeated.Keys().All())
+	assert.Equal(t, []interface{}{"Ready", "You"}, repeated.Values().All())
+}
+
+func TestCollectionFlatMap(t *testing.T) {
+	words := Collect([]string{"a b", "c", "d e"}).FlatMap(func(value interface{}, key interface{}, index int) interface{} {
+		return strings.Split(value.(string), " ")
+	})
+	assert.Equal(t, []interface{}{0, 1, 2, 3, 4}, words.Keys().All())
+	assert.Equal(t, []interface{}{"a", "b", "c", "d", "e"}, words.Values().All())
+
+	mixed := Collect(arrMap).FlatMap(func(value interface{}, key interface{}, index int) interface{} {
+		if index == 0 {
+			return Collect([]interface{}{key, value})
+		}
+		return value
+	})
+	assert.Equal(t, []interface{}{"Age", 28, "John", "Doe"}, mixed.Values().All())
+}
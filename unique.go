@@ -0,0 +1,41 @@
+package collection
+
+import (
+	"github.com/habibimustafa/collection/arr"
+	"github.com/habibimustafa/collection/deep"
+)
+
+// Unique removes items whose value repeats an earlier item,
+// numbers of different types with the same value are treated as repeats
+func (c collect) Unique() Collection {
+	return c.dedupe(deep.NewNumericSet(), func(value interface{}, key interface{}, index int) interface{} { return value })
+}
+
+// UniqueBy removes items whose callback result repeats an earlier item
+func (c collect) UniqueBy(callback func(value interface{}, key interface{}, index int) interface{}) Collection {
+	return c.dedupe(deep.NewNumericSet(), callback)
+}
+
+// DedupeStrict removes items whose value repeats an earlier item,
+// values must have the same type to be treated as repeats
+func (c collect) DedupeStrict() Collection {
+	return c.dedupe(deep.NewSet(), func(value interface{}, key interface{}, index int) interface{} { return value })
+}
+
+// Duplicates gets the keys of items whose value repeats an earlier item
+func (c collect) Duplicates() arr.Array {
+	keys := arr.Array{}
+	seen := deep.NewNumericSet()
+	for i := 0; i < c.Size(); i++ {
+		if !seen.Add(c.values[i]) {
+			keys = append(keys, c.keys[i])
+		}
+	}
+	return keys
+}
+
+func (c collect) dedupe(seen *deep.Set, callback func(value interface{}, key interface{}, index int) interface{}) Collection {
+	return c.Filter(func(value interface{}, key interface{}, index int) bool {
+		return seen.Add(callback(value, key, index))
+	})
+}
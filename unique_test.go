@@ -0,0 +1,40 @@
+package collection
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCollectionUnique(t *testing.T) {
+	unique := Collect([]interface{}{"a", 1, "b", 1.0, "a", []int{1}, []int{1}}).Unique()
+	assert.Equal(t, []interface{}{0, 1, 2, 5}, unique.Keys().All())
+	assert.Equal(t, []interface{}{"a", 1, "b", []int{1}}, unique.Values().All())
+
+	unique = Collect(map[string]interface{}{"x": 1, "y": 2, "z": 1}).Unique()
+	assert.Equal(t, []interface{}{"x", "y"}, unique.Keys().All())
+}
+
+func TestCollectionUniqueBy(t *testing.T) {
+	users := Collect([]map[string]interface{}{
+		{"name": "John", "team": "red"},
+		{"name": "Jane", "team": "blue"},
+		{"name": "Jack", "team": "red"},
+	})
+
+	unique := users.UniqueBy(func(value interface{}, key interface{}, index int) interface{} {
+		return value.(map[string]interface{})["team"]
+	})
+
+	assert.Equal(t, []interface{}{0, 1}, unique.Keys().All())
+}
+
+func TestCollectionDedupeStrict(t *testing.T) {
+	unique := Collect([]interface{}{1, 1.0, int64(1), 1, []string{"a"}, []string{"a"}}).DedupeStrict()
+	assert.Equal(t, []interface{}{0, 1, 2, 4}, unique.Keys().All())
+}
+
+func TestCollectionDuplicates(t *testing.T) {
+	duplicates := Collect(map[string]interface{}{"a": []int{1}, "b": 2, "c": []int{1}, "d": 2.0, "e": 3}).Duplicates()
+	assert.Equal(t, []interface{}{"c", "d"}, duplicates.All())
+	assert.Empty(t, Collect(arrString).Duplicates())
+}
@@ -0,0 +1,280 @@
+package collection
+
+import (
+	"errors"
+	"fmt"
+	"github.com/habibimustafa/collection/arr"
+	"github.com/habibimustafa/collection/deep"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Rule checks the value found at a path, present is false when the path does not exist.
+// A rule may return ValidationErrors to report errors of nested paths.
+type Rule func(value interface{}, present bool) error
+
+// ValidationErrors holds the error messages of a validation keyed by path, an empty collection means valid
+type ValidationErrors struct {
+	Collection
+}
+
+// Error joins all error messages with their path
+func (v ValidationErrors) Error() string {
+	var messages []string
+	v.Each(func(value interface{}, key interface{}, index int) {
+		for _, message := range value.(arr.Array) {
+			messages = append(messages, fmt.Sprintf("%v: %v", key, message))
+		}
+	})
+	return strings.Join(messages, "; ")
+}
+
+// add appends the error message of the path
+func (v ValidationErrors) add(path string, err error) ValidationErrors {
+	var nested ValidationErrors
+	if errors.As(err, &nested) {
+		nested.Each(func(value interface{}, key interface{}, index int) {
+			for _, message := range value.(arr.Array) {
+				v = v.add(joinPath(path, key.(string)), errors.New(message.(string)))
+			}
+		})
+		return v
+	}
+
+	messages, _ := v.GetValue(path).(arr.Array)
+	return ValidationErrors{v.Set(path, append(arr.Array{}, append(messages, err.Error())...))}
+}
+
+func joinPath(path string, key string) string {
+	switch {
+	case path == "":
+		return key
+	case key == "":
+		return path
+	default:
+		return path + "." + key
+	}
+}
+
+// Validate checks the values at the dot paths of the rules, a "*" segment matches every key.
+// Paths are checked in sorted order and the errors are keyed by the concrete path.
+func (c collect) Validate(rules map[string]Rule) ValidationErrors {
+	return validate(c, rules)
+}
+
+func validate(value interface{}, rules map[string]Rule) ValidationErrors {
+	paths := make([]string, 0, len(rules))
+	for path := range rules {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	errs := ValidationErrors{Collect(nil)}
+	for _, path := range paths {
+		for _, match := range resolve(value, "", strings.Split(path, ".")) {
+			if err := rules[path](match.value, match.present); err != nil {
+				errs = errs.add(match.path, err)
+			}
+		}
+	}
+	return errs
+}
+
+type pathMatch struct {
+	path    string
+	value   interface{}
+	present bool
+}
+
+// resolve finds the values at the path segments, expanding "*" into every key
+func resolve(value interface{}, path string, segments []string) []pathMatch {
+	if len(segments) == 0 {
+		return []pathMatch{{path: path, value: value, present: true}}
+	}
+
+	if segments[0] == "*" {
+		keys, _ := children(value)
+		var matches []pathMatch
+		for _, key := range keys {
+			matches = append(matches, resolve(value, path, append([]string{key}, segments[1:]...))...)
+		}
+		return matches
+	}
+
+	next, ok := child(value, segments[0])
+	if !ok {
+		return []pathMatch{{path: joinPath(path, strings.Join(segments, "."))}}
+	}
+	return resolve(next, joinPath(path, segments[0]), segments[1:])
+}
+
+// Rules combines the rules, the errors of every rule are reported
+func Rules(rules ...Rule) Rule {
+	return func(value interface{}, present bool) error {
+		errs := ValidationErrors{Collect(nil)}
+		for _, rule := range rules {
+			if err := rule(value, present); err != nil {
+				errs = errs.add("", err)
+			}
+		}
+
+		if errs.Size() == 0 {
+			return nil
+		}
+		return errs
+	}
+}
+
+// Required fails when the value is missing, nil, or empty
+func Required() Rule {
+	return func(value interface{}, present bool) error {
+		if !present || value == nil || isEmpty(value) {
+			return errors.New("is required")
+		}
+		return nil
+	}
+}
+
+// The rules below pass when the value is missing or nil, combine them with Required to reject such values
+
+// Type fails when the value is not of the kind
+func Type(kind reflect.Kind) Rule {
+	return optional(func(value interface{}) error {
+		if reflect.ValueOf(value).Kind() != kind {
+			return fmt.Errorf("must be of type %s", kind)
+		}
+		return nil
+	})
+}
+
+// Min fails when the value is not a number or is less than min
+func Min(min float64) Rule {
+	return optional(func(value interface{}) error {
+		n, ok := toFloat(value)
+		if !ok {
+			return errors.New("must be a number")
+		}
+
+		if n < min {
+			return fmt.Errorf("must be at least %v", min)
+		}
+		return nil
+	})
+}
+
+// Max fails when the value is not a number or is greater than max
+func Max(max float64) Rule {
+	return optional(func(value interface{}) error {
+		n, ok := toFloat(value)
+		if !ok {
+			return errors.New("must be a number")
+		}
+
+		if n > max {
+			return fmt.Errorf("must be at most %v", max)
+		}
+		return nil
+	})
+}
+
+// Length fails when the length of a string, slice, map, or collection is not between min and max,
+// a negative max means there is no upper limit
+func Length(min int, max int) Rule {
+	return optional(func(value interface{}) error {
+		length, ok := lengthOf(value)
+		if !ok {
+			return errors.New("must have a length")
+		}
+
+		if length < min || (max >= 0 && length > max) {
+			if max < 0 {
+				return fmt.Errorf("length must be at least %d", min)
+			}
+			return fmt.Errorf("length must be between %d and %d", min, max)
+		}
+		return nil
+	})
+}
+
+// Regex fails when the value is not a string matching the pattern
+func Regex(pattern string) Rule {
+	re := regexp.MustCompile(pattern)
+	return optional(func(value interface{}) error {
+		s, ok := value.(string)
+		if !ok || !re.MatchString(s) {
+			return fmt.Errorf("must match %s", pattern)
+		}
+		return nil
+	})
+}
+
+// In fails when the value is not one of the values, numbers are compared by value
+func In(values ...interface{}) Rule {
+	return optional(func(value interface{}) error {
+		for _, v := range values {
+			if deep.EqualNumeric(v, value) {
+				return nil
+			}
+		}
+		return fmt.Errorf("must be one of %v", values)
+	})
+}
+
+// Nested validates the values inside a nested collection or map with the rules
+func Nested(rules map[string]Rule) Rule {
+	return optional(func(value interface{}) error {
+		if _, ok := children(value); !ok {
+			return errors.New("must be a collection or map")
+		}
+
+		if errs := validate(value, rules); errs.Size() > 0 {
+			return errs
+		}
+		return nil
+	})
+}
+
+// optional skips the check when the value is missing or nil
+func optional(check func(value interface{}) error) Rule {
+	return func(value interface{}, present bool) error {
+		if !present || value == nil {
+			return nil
+		}
+		return check(value)
+	}
+}
+
+func isEmpty(value interface{}) bool {
+	length, ok := lengthOf(value)
+	return ok && length == 0
+}
+
+func lengthOf(value interface{}) (int, bool) {
+	if c, ok := value.(Collection); ok {
+		return c.Size(), true
+	}
+
+	val := reflect.ValueOf(value)
+	switch val.Kind() {
+	case reflect.String:
+		return len([]rune(val.String())), true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return val.Len(), true
+	}
+	return 0, false
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	val := reflect.ValueOf(value)
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(val.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(val.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return val.Float(), true
+	}
+	return 0, false
+}
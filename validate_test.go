@@ -0,0 +1,93 @@
+package collection
+
+import (
+	"github.com/habibimustafa/collection/arr"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func payload() Collection {
+	return Collect(map[string]interface{}{
+		"name":  "John",
+		"email": "john@example",
+		"age":   15,
+		"role":  "owner",
+		"tags":  []string{"a", ""},
+		"address": map[string]interface{}{
+			"city": "",
+			"zip":  "12345",
+		},
+	}).Append("company", Collect(map[string]interface{}{"name": "Acme", "size": 0}))
+}
+
+func TestCollectionValidate(t *testing.T) {
+	errs := payload().Validate(map[string]Rule{
+		"name":          Rules(Required(), Type(reflect.String), Length(2, 10)),
+		"email":         Rules(Required(), Regex(`^[^@]+@[^@]+\.[a-z]+$`)),
+		"age":           Rules(Required(), Min(18), Max(99)),
+		"role":          In("admin", "member"),
+		"phone":         Required(),
+		"nickname":      Length(2, 10),
+		"tags.*":        Required(),
+		"address.city":  Required(),
+		"address.zip":   Length(5, 5),
+		"company.size":  Min(1),
+		"company.name":  Required(),
+		"company.owner": Type(reflect.String),
+	})
+
+	assert.Equal(t, []interface{}{"address.city", "age", "company.size", "email", "phone", "role", "tags.1"}, errs.Keys().All())
+	assert.Equal(t, arr.Array{"is required"}, errs.GetValue("address.city"))
+	assert.Equal(t, arr.Array{"must be at least 18"}, errs.GetValue("age"))
+	assert.Equal(t, arr.Array{"must be at least 1"}, errs.GetValue("company.size"))
+	assert.Equal(t, arr.Array{`must match ^[^@]+@[^@]+\.[a-z]+$`}, errs.GetValue("email"))
+	assert.Equal(t, arr.Array{"is required"}, errs.GetValue("phone"))
+	assert.Equal(t, arr.Array{"must be one of [admin member]"}, errs.GetValue("role"))
+	assert.Equal(t, arr.Array{"is required"}, errs.GetValue("tags.1"))
+	assert.Equal(t, "address.city: is required; age: must be at least 18; company.size: must be at least 1; "+
+		`email: must match ^[^@]+@[^@]+\.[a-z]+$; phone: is required; role: must be one of [admin member]; tags.1: is required`, errs.Error())
+}
+
+func TestCollectionValidateCombinedRules(t *testing.T) {
+	errs := Collect(map[string]interface{}{"code": "x"}).Validate(map[string]Rule{
+		"code": Rules(Length(3, -1), Regex(`^\d+$`), Type(reflect.Int)),
+	})
+
+	assert.Equal(t, arr.Array{"length must be at least 3", `must match ^\d+$`, "must be of type int"}, errs.GetValue("code"))
+}
+
+func TestCollectionValidateNested(t *testing.T) {
+	address := Nested(map[string]Rule{
+		"city": Required(),
+		"zip":  Rules(Required(), Length(5, 5)),
+	})
+
+	errs := payload().Validate(map[string]Rule{
+		"address": address,
+		"company": Nested(map[string]Rule{"name": Required()}),
+		"name":    Nested(map[string]Rule{}),
+	})
+
+	assert.Equal(t, []interface{}{"address.city", "name"}, errs.Keys().All())
+	assert.Equal(t, arr.Array{"must be a collection or map"}, errs.GetValue("name"))
+
+	errs = Collect([]interface{}{
+		map[string]interface{}{"city": "Westview", "zip": "123"},
+		Collect(map[string]interface{}{"city": "Westview", "zip": "12345"}),
+	}).Validate(map[string]Rule{"*": address})
+
+	assert.Equal(t, []interface{}{"0.zip"}, errs.Keys().All())
+	assert.Equal(t, arr.Array{"length must be between 5 and 5"}, errs.GetValue("0.zip"))
+}
+
+func TestCollectionValidateValid(t *testing.T) {
+	errs := payload().Validate(map[string]Rule{
+		"name":         Required(),
+		"age":          Rules(Min(10), Max(20), In(15.0, 16)),
+		"company.name": Rules(Required(), In("Acme")),
+	})
+
+	assert.Equal(t, 0, errs.Size())
+	assert.Equal(t, "", errs.Error())
+}
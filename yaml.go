@@ -0,0 +1,162 @@
+package collection
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v3"
+)
+
+// MarshalYAML encodes the collection as a YAML mapping keeping the order of the items,
+// nested collections are encoded the same way
+func (c collect) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for i := range c.keys {
+		key, value := &yaml.Node{}, &yaml.Node{}
+		if err := key.Encode(c.keys[i]); err != nil {
+			return nil, err
+		}
+		if err := value.Encode(c.values[i]); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, key, value)
+	}
+	return node, nil
+}
+
+// UnmarshalYAML decodes a YAML mapping or sequence into the collection, see FromYAML
+func (c *collect) UnmarshalYAML(node *yaml.Node) error {
+	value, err := readYAML(node)
+	if err != nil {
+		return err
+	}
+
+	switch v := value.(type) {
+	case collect:
+		*c = v
+	case []interface{}:
+		*c = Collect(v).(collect)
+	case nil:
+		*c = collect{}
+	default:
+		return fmt.Errorf("collection: cannot decode YAML %T into a collection", value)
+	}
+	return nil
+}
+
+// FromYAML decodes a YAML mapping into a collection keeping the order of the keys in the document.
+// Nested mappings become collections and sequences become []interface{}.
+// A top-level sequence becomes a collection keyed by the positions of its items.
+// The keys of a mapping must have the same type, and must not repeat.
+// Merge keys such as << : *defaults add the items of the merged mappings first,
+// and the keys written in the mapping itself replace their values.
+func FromYAML(data []byte) (Collection, error) {
+	c := collect{}
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func readYAML(node *yaml.Node) (interface{}, error) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil, nil
+		}
+		return readYAML(node.Content[0])
+	case yaml.AliasNode:
+		return readYAML(node.Alias)
+	case yaml.MappingNode:
+		c, err := readYAMLMerges(node)
+		if err != nil {
+			return nil, err
+		}
+
+		own := collect{}.build()
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].ShortTag() == mergeTag {
+				continue
+			}
+
+			var key interface{}
+			if err := node.Content[i].Decode(&key); err != nil {
+				return nil, err
+			}
+
+			err := own.check(key)
+			if err == nil {
+				err = c.checkKeyType(key)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("collection: line %d: cannot add YAML key %v: %v", node.Content[i].Line, key, err)
+			}
+
+			value, err := readYAML(node.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+
+			own.add(key, nil)
+			if index := c.indexOf(key); index > -1 {
+				c.values[index] = value
+				continue
+			}
+			c.add(key, value)
+		}
+		return c.collect, nil
+	case yaml.SequenceNode:
+		items := []interface{}{}
+		for _, item := range node.Content {
+			value, err := readYAML(item)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, value)
+		}
+		return items, nil
+	default:
+		var value interface{}
+		err := node.Decode(&value)
+		return value, err
+	}
+}
+
+const mergeTag = "!!merge"
+
+// readYAMLMerges starts a mapping with the items of its merge keys, such as << : *defaults.
+// A merge key holds a mapping or a sequence of mappings, and the first mapping having a key gives its value.
+func readYAMLMerges(node *yaml.Node) (*builder, error) {
+	c := collect{}.build()
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].ShortTag() != mergeTag {
+			continue
+		}
+
+		sources := []*yaml.Node{node.Content[i+1]}
+		if node.Content[i+1].Kind == yaml.SequenceNode {
+			sources = node.Content[i+1].Content
+		}
+
+		for _, source := range sources {
+			value, err := readYAML(source)
+			if err != nil {
+				return nil, err
+			}
+
+			merged, ok := value.(collect)
+			if !ok {
+				return nil, fmt.Errorf("collection: line %d: a YAML merge key needs a mapping or a sequence of mappings", source.Line)
+			}
+
+			for j, key := range merged.keys {
+				if err := c.checkKeyType(key); err != nil {
+					return nil, fmt.Errorf("collection: line %d: cannot merge YAML key %v: %v", source.Line, key, err)
+				}
+
+				if c.indexOf(key) < 0 {
+					c.add(key, merged.values[j])
+				}
+			}
+		}
+	}
+	return c, nil
+}
@@ -0,0 +1,118 @@
+package collection
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+const config = `name: app
+port: 8080
+database:
+  user: root
+  host: localhost
+tags:
+  - web
+  - api
+defaults: &defaults
+  retries: 3
+worker: *defaults
+`
+
+func TestFromYAML(t *testing.T) {
+	c, err := FromYAML([]byte(config))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []interface{}{"name", "port", "database", "tags", "defaults", "worker"}, c.Keys().All())
+	assert.Equal(t, 8080, c.GetValue("port"))
+	assert.Equal(t, []interface{}{"user", "host"}, c.GetValue("database").(Collection).Keys().All())
+	assert.Equal(t, []interface{}{"web", "api"}, c.GetValue("tags"))
+	assert.Equal(t, 3, c.GetValue("worker").(Collection).GetValue("retries"))
+
+	list, err := FromYAML([]byte("- a\n- b\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{0, 1}, list.Keys().All())
+
+	empty, err := FromYAML([]byte(""))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, empty.Size())
+}
+
+func TestFromYAMLMergeKeys(t *testing.T) {
+	c, err := FromYAML([]byte(`base: &base
+  host: localhost
+  port: 5432
+extra: &extra
+  port: 6432
+  pool: 5
+primary:
+  <<: *base
+  port: 5433
+  name: main
+replica:
+  name: copy
+  <<: [*extra, *base]
+`))
+	assert.NoError(t, err)
+
+	primary := c.GetValue("primary").(Collection)
+	assert.Equal(t, []interface{}{"host", "port", "name"}, primary.Keys().All())
+	assert.Equal(t, []interface{}{"localhost", 5433, "main"}, primary.Values().All())
+
+	replica := c.GetValue("replica").(Collection)
+	assert.Equal(t, []interface{}{"port", "pool", "host", "name"}, replica.Keys().All())
+	assert.Equal(t, []interface{}{6432, 5, "localhost", "copy"}, replica.Values().All())
+	assert.False(t, c.GetValue("base").(Collection).Has("name"))
+
+	_, err = FromYAML([]byte("a:\n  <<: 1\n"))
+	assert.EqualError(t, err, "collection: line 2: a YAML merge key needs a mapping or a sequence of mappings")
+
+	_, err = FromYAML([]byte("a:\n  <<: {x: 1}\n  x: 2\n  x: 3\n"))
+	assert.EqualError(t, err, "collection: line 4: cannot add YAML key x: the new key is already exists")
+}
+
+func TestFromYAMLErrors(t *testing.T) {
+	_, err := FromYAML([]byte("a: 1\na: 2\n"))
+	assert.Error(t, err)
+
+	_, err = FromYAML([]byte("a: 1\n2: b\n"))
+	assert.EqualError(t, err, "collection: line 2: cannot add YAML key 2: the new key type is different")
+
+	_, err = FromYAML([]byte("a: 1\n~: 2\n"))
+	assert.EqualError(t, err, "collection: line 2: cannot add YAML key <nil>: the new key must not be nil")
+
+	_, err = FromYAML([]byte("~: 1\n"))
+	assert.EqualError(t, err, "collection: line 1: cannot add YAML key <nil>: the new key must not be nil")
+
+	_, err = FromYAML([]byte("hello"))
+	assert.EqualError(t, err, "collection: cannot decode YAML string into a collection")
+}
+
+func TestCollectionMarshalYAML(t *testing.T) {
+	c, err := FromYAML([]byte(config))
+	assert.NoError(t, err)
+
+	encoded, err := yaml.Marshal(c)
+	assert.NoError(t, err)
+	assert.Equal(t, `name: app
+port: 8080
+database:
+    user: root
+    host: localhost
+tags:
+    - web
+    - api
+defaults:
+    retries: 3
+worker:
+    retries: 3
+`, string(encoded))
+
+	decoded, err := FromYAML(encoded)
+	assert.NoError(t, err)
+	assert.True(t, c.DeepEquals(decoded))
+
+	encoded, err = yaml.Marshal(struct{ Items Collection }{Collect(nil)})
+	assert.NoError(t, err)
+	assert.Equal(t, "items: {}\n", string(encoded))
+}
@@ -0,0 +1,134 @@
+package collection
+
+import (
+	"github.com/habibimustafa/collection/arr"
+	"math"
+	"reflect"
+)
+
+// Combine creates a collection by using the values of keys as keys and the values of values as values
+func Combine(keys Collection, values Collection) Collection {
+	if keys.Size() != values.Size() {
+		panic("the number of keys and values must be equal")
+	}
+
+	c := collect{}.build()
+	items := values.Values()
+	for i, key := range keys.Values() {
+		if err := c.check(key); err != nil {
+			panic(err.Error())
+		}
+		c.add(key, items[i])
+	}
+	return c.collect
+}
+
+// Zip merges the values of the other collections with the values at the same position,
+// each value becomes an array with nil in place of missing values
+func (c collect) Zip(others ...Collection) Collection {
+	columns := make([]arr.Array, len(others))
+	for j, other := range others {
+		columns[j] = other.Values()
+	}
+
+	values := make([]interface{}, c.Size())
+	for i := range values {
+		tuple := arr.Array{c.values[i]}
+		for _, column := range columns {
+			var value interface{}
+			if i < len(column) {
+				value = column[i]
+			}
+			tuple = append(tuple, value)
+		}
+		values[i] = tuple
+	}
+
+	return c.with(c.Keys().All(), values)
+}
+
+// CrossJoin gets the cartesian product of the values with the values of the other collections
+func (c collect) CrossJoin(others ...Collection) Collection {
+	tuples := []arr.Array{{}}
+	for _, collection := range append([]Collection{c}, others...) {
+		var product []arr.Array
+		for _, tuple := range tuples {
+			for _, value := range collection.Values() {
+				product = append(product, append(append(arr.Array{}, tuple...), value))
+			}
+		}
+		tuples = product
+	}
+
+	var product collect
+	for _, tuple := range tuples {
+		product = product.push(tuple)
+	}
+	return product
+}
+
+// Pad fills the collection with the value until it reaches the size,
+// a negative size pads at the beginning. The collection keys must be integers,
+// new keys continue after the largest key or before the smallest key,
+// it panics when the new keys do not fit in the key type.
+func (c collect) Pad(size int, value interface{}) Collection {
+	count := size
+	if count < 0 {
+		count = -count
+	}
+
+	if count <= c.Size() {
+		return c
+	}
+
+	keyType := reflect.TypeOf(0)
+	if c.Size() > 0 {
+		keyType = reflect.TypeOf(c.keys[0])
+	}
+
+	switch keyType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+	default:
+		panic("the collection keys must be integers to be padded")
+	}
+
+	min, max := int64(0), int64(-1)
+	for i, key := range c.keys {
+		n := reflect.ValueOf(key).Int()
+		if i == 0 || n < min {
+			min = n
+		}
+		if i == 0 || n > max {
+			max = n
+		}
+	}
+
+	after := size > 0 || c.Size() == 0
+	missing := int64(count - c.Size())
+	first := max + 1
+	if !after {
+		first = min - missing
+	}
+
+	wrapped := after && max > math.MaxInt64-missing || !after && min < math.MinInt64+missing
+	if zero := reflect.Zero(keyType); wrapped || zero.OverflowInt(first) || zero.OverflowInt(first+missing-1) {
+		panic("the padded keys overflow the key type")
+	}
+
+	keys := make([]interface{}, missing)
+	values := make([]interface{}, missing)
+	for i := range keys {
+		keys[i], values[i] = reflect.ValueOf(first+int64(i)).Convert(keyType).Interface(), value
+	}
+
+	padded := c.slice(0, c.Size())
+	if after {
+		padded.keys = append(padded.keys, keys...)
+		padded.values = append(padded.values, values...)
+	} else {
+		padded.keys = append(keys, padded.keys...)
+		padded.values = append(values, padded.values...)
+	}
+
+	return padded
+}
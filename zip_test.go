@@ -0,0 +1,66 @@
+package collection
+
+import (
+	"github.com/habibimustafa/collection/arr"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestCombine(t *testing.T) {
+	headers := Collect([]string{"name", "age", "city"})
+	row := Collect([]interface{}{"John", 28, "Westview"})
+
+	combined := Combine(headers, row)
+	assert.Equal(t, []interface{}{"name", "age", "city"}, combined.Keys().All())
+	assert.Equal(t, []interface{}{"John", 28, "Westview"}, combined.Values().All())
+	assert.Equal(t, 28, combined.GetValue("age"))
+
+	assert.PanicsWithValue(t, "the number of keys and values must be equal", func() { Combine(headers, Collect([]int{1})) })
+	assert.PanicsWithValue(t, "the new key is already exists", func() { Combine(Collect([]string{"a", "a"}), Collect([]int{1, 2})) })
+	assert.PanicsWithValue(t, "the new key type is different", func() { Combine(Collect([]interface{}{"a", 1}), Collect([]int{1, 2})) })
+}
+
+func TestCollectionZip(t *testing.T) {
+	zipped := Collect(arrMap).Zip(Collect([]int{1, 2, 3}), Collect([]string{"x"}))
+	assert.Equal(t, []interface{}{"Age", "First Name", "Last Name"}, zipped.Keys().All())
+	assert.Equal(t, arr.Array{28, 1, "x"}, zipped.GetValue("Age"))
+	assert.Equal(t, arr.Array{"John", 2, nil}, zipped.GetValue("First Name"))
+	assert.Equal(t, arr.Array{"Doe", 3, nil}, zipped.GetValue("Last Name"))
+}
+
+func TestCollectionCrossJoin(t *testing.T) {
+	product := Collect([]int{1, 2}).CrossJoin(Collect([]string{"a", "b"}), Collect([]bool{true}))
+	assert.Equal(t, []interface{}{0, 1, 2, 3}, product.Keys().All())
+	assert.Equal(t, []interface{}{
+		arr.Array{1, "a", true},
+		arr.Array{1, "b", true},
+		arr.Array{2, "a", true},
+		arr.Array{2, "b", true},
+	}, product.Values().All())
+
+	assert.Equal(t, 0, Collect([]int{1, 2}).CrossJoin(Collect(nil)).Size())
+}
+
+func TestCollectionPad(t *testing.T) {
+	padded := Collect([]string{"a", "b"}).Pad(4, "-")
+	assert.Equal(t, []interface{}{0, 1, 2, 3}, padded.Keys().All())
+	assert.Equal(t, []interface{}{"a", "b", "-", "-"}, padded.Values().All())
+
+	padded = Collect([]string{"a", "b"}).Pad(-4, "-")
+	assert.Equal(t, []interface{}{-2, -1, 0, 1}, padded.Keys().All())
+	assert.Equal(t, []interface{}{"-", "-", "a", "b"}, padded.Values().All())
+
+	padded = Collect(map[int64]string{5: "a"}).Pad(2, "-")
+	assert.Equal(t, []interface{}{int64(5), int64(6)}, padded.Keys().All())
+
+	padded = Collect(nil).Pad(-2, 0)
+	assert.Equal(t, []interface{}{0, 1}, padded.Keys().All())
+
+	assert.Equal(t, 5, Collect(arrString).Pad(3, "-").Size())
+	assert.PanicsWithValue(t, "the collection keys must be integers to be padded", func() { Collect(arrMap).Pad(5, "-") })
+	assert.PanicsWithValue(t, "the padded keys overflow the key type", func() { Collect(map[int8]string{127: "a"}).Pad(3, "-") })
+	assert.PanicsWithValue(t, "the padded keys overflow the key type", func() { Collect(map[int8]string{-127: "a"}).Pad(-3, "-") })
+	assert.PanicsWithValue(t, "the padded keys overflow the key type", func() { Collect(map[int64]string{math.MaxInt64: "a"}).Pad(2, "-") })
+	assert.Equal(t, []interface{}{int8(-128), int8(-127)}, Collect(map[int8]string{-127: "a"}).Pad(-2, "-").Keys().All())
+}